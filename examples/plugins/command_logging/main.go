@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 
 	json "encoding/json"
@@ -36,6 +38,11 @@ func (h *handler) Metacommand(ctx context.Context, req *proto.MetacommandRequest
 		err  error
 	)
 
+	if req.CompleteArg != "" {
+		resp.Data, err = json.Marshal(h.complete(req.MetaCommand, req.CompleteArg))
+		return &resp, err
+	}
+
 	if len(h.history) == 0 {
 		return &resp, nil
 	}
@@ -44,6 +51,9 @@ func (h *handler) Metacommand(ctx context.Context, req *proto.MetacommandRequest
 	case "history":
 		var items = []map[string]string{}
 		for _, item := range h.history {
+			if 0 < len(req.Args) && !strings.HasPrefix(item, req.Args[0]) {
+				continue
+			}
 			i := map[string]string{
 				"title":        item,
 				"filter_value": item,
@@ -54,6 +64,74 @@ func (h *handler) Metacommand(ctx context.Context, req *proto.MetacommandRequest
 		resp.Data, err = json.Marshal(items)
 	case "last":
 		resp.Data = []byte(h.history[len(h.history)-1])
+	case "show":
+		var (
+			out   = &strings.Builder{}
+			first = 0
+		)
+		if 0 < len(req.Args) {
+			limit, _ := strconv.Atoi(req.Args[0])
+			first = max(len(h.history)-limit, 0)
+		}
+		for idx, item := range h.history[first:] {
+			fmt.Fprintf(out, "%5d  %s\n", first+idx+1, item)
+		}
+		resp.Data = []byte(out.String())
+	case "counts":
+		var (
+			counts = map[string]int{}
+			items  = []map[string]string{}
+		)
+		var runs = map[string][]string{}
+		for idx, item := range h.history {
+			if counts[item] == 0 {
+				items = append(items, map[string]string{
+					"title":        item,
+					"filter_value": item,
+				})
+			}
+			counts[item]++
+			runs[item] = append(runs[item], fmt.Sprintf("#%d", idx+1))
+		}
+		for _, item := range items {
+			item["description"] = fmt.Sprintf("ran %d times", counts[item["title"]])
+			item["preview"] = "ran as " + strings.Join(runs[item["title"]], ", ")
+		}
+		if 0 < len(req.Args) {
+			sort.SliceStable(items, func(i, j int) bool {
+				if req.Args[0] == "name" {
+					return items[i]["title"] < items[j]["title"]
+				}
+				return counts[items[i]["title"]] > counts[items[j]["title"]]
+			})
+		}
+		resp.Data, err = json.Marshal(items)
+	case "top":
+		resp.Data = []byte(h.top(req.FormatArgs))
+	case "context":
+		if len(req.Args) == 0 {
+			err = errors.New("missing command")
+			break
+		}
+		resp.Data = []byte(h.context(req.Args[0]))
+	case "recall":
+		// complete the command line being typed from the history
+		for idx := len(h.history) - 1; 0 <= idx; idx-- {
+			if strings.HasPrefix(h.history[idx], req.CommandLine) {
+				resp.Data = []byte(h.history[idx])
+				break
+			}
+		}
+		if resp.Data == nil {
+			err = fmt.Errorf("no command starts with %q", req.CommandLine)
+		}
+	case "search":
+		// the form is filled in and sent back as form values
+		if req.FormValues == nil {
+			resp.Data, err = json.Marshal(searchForm)
+			break
+		}
+		resp.Data = []byte(h.search(req.FormValues))
 	default:
 		resp.Error = "unknown command"
 		err = errors.New(resp.Error)
@@ -70,6 +148,152 @@ func (h *handler) Metacommand(ctx context.Context, req *proto.MetacommandRequest
 	return &resp, err
 }
 
+// context lists the commands run around the last run of command.
+func (h *handler) context(command string) string {
+	last := -1
+	for idx, item := range h.history {
+		if item == command {
+			last = idx
+		}
+	}
+	if last == -1 {
+		return command + " has not been run"
+	}
+
+	var out strings.Builder
+	for idx := max(last-5, 0); idx < min(last+6, len(h.history)); idx++ {
+		marker := " "
+		if idx == last {
+			marker = ">"
+		}
+		fmt.Fprintf(&out, "%s %5d  %s\n", marker, idx+1, h.history[idx])
+	}
+	return out.String()
+}
+
+var searchForm = map[string]any{
+	"title":         "search the history",
+	"submit_format": "TEXT",
+	"fields": []map[string]any{
+		{
+			"name":        "contains",
+			"label":       "contains",
+			"description": "text the commands contain",
+			"required":    true,
+		},
+		{
+			"name":        "limit",
+			"type":        "int",
+			"default":     "10",
+			"description": "most commands to list, 0 listing them all",
+			"pattern":     "^[0-9]+$",
+		},
+		{
+			"name":    "order",
+			"type":    "enum",
+			"options": []string{"newest", "oldest"},
+		},
+		{
+			"name":        "unique",
+			"type":        "bool",
+			"default":     "true",
+			"description": "list each command once",
+		},
+	},
+}
+
+// search lists the commands matching the values of the search form.
+func (h *handler) search(values map[string]string) string {
+	var (
+		limit, _ = strconv.Atoi(values["limit"])
+		seen     = map[string]bool{}
+		out      strings.Builder
+		n        int
+	)
+	for i := range h.history {
+		idx := len(h.history) - 1 - i
+		if values["order"] == "oldest" {
+			idx = i
+		}
+		item := h.history[idx]
+		if !strings.Contains(item, values["contains"]) || (values["unique"] == "true" && seen[item]) {
+			continue
+		}
+		seen[item] = true
+		fmt.Fprintf(&out, "%5d  %s\n", idx+1, item)
+		if n++; n == limit {
+			break
+		}
+	}
+	if n == 0 {
+		return "no command contains " + values["contains"]
+	}
+	return out.String()
+}
+
+// complete returns the values the dynamic argument arg of metacommand can take.
+func (h *handler) complete(metacommand, arg string) []string {
+	var (
+		values = []string{}
+		seen   = map[string]bool{}
+	)
+
+	switch metacommand + "/" + arg {
+	case "history/prefix":
+		for _, item := range h.history {
+			if fields := strings.Fields(item); 0 < len(fields) && !seen[fields[0]] {
+				seen[fields[0]] = true
+				values = append(values, fields[0])
+			}
+		}
+	}
+
+	return values
+}
+
+// top draws a bar chart of the most frequently run commands,
+// sized to fit the screen given by the size=WxH format arg.
+func (h *handler) top(formatArgs []string) string {
+	var w, ht = 80, 24
+	for _, arg := range formatArgs {
+		if size, ok := strings.CutPrefix(arg, "size="); ok {
+			fmt.Sscanf(size, "%dx%d", &w, &ht)
+		}
+	}
+
+	var (
+		counts   = map[string]int{}
+		commands []string
+	)
+	for _, item := range h.history {
+		if counts[item] == 0 {
+			commands = append(commands, item)
+		}
+		counts[item]++
+	}
+	sort.SliceStable(commands, func(i, j int) bool {
+		return counts[commands[i]] > counts[commands[j]]
+	})
+
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "\x1b[1m%-*s\x1b[0m\n", w, "most used commands")
+	for _, cmd := range commands {
+		var (
+			barWidth = w / 3
+			bar      = counts[cmd] * barWidth / counts[commands[0]]
+			line     = fmt.Sprintf("%5d %s", counts[cmd], cmd)
+		)
+		if len(line) > w-barWidth-1 {
+			line = line[:w-barWidth-1]
+		}
+		fmt.Fprintf(out, "\x1b[36m%s\x1b[0m%*s %s\n",
+			strings.Repeat("█", bar), barWidth-bar, "", line,
+		)
+	}
+
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
 func (h *handler) Info(ctx context.Context) (*proto.PluginInfo, error) {
 	return &proto.PluginInfo{
 		Name:                  "logging",
@@ -77,13 +301,75 @@ func (h *handler) Info(ctx context.Context) (*proto.PluginInfo, error) {
 		AcceptsCommandReports: true,
 		Metacommands: []*proto.MetacommandInfo{
 			{
-				Name:   "history",
-				Format: proto.MetacommandResponseFormat_SHELL_INJECTION_LIST,
+				Name:        "history",
+				Format:      proto.MetacommandResponseFormat_SHELL_INJECTION_LIST,
+				Description: "pick commands from the history, space marking several",
+				MultiSelect: true,
+				Separator:   " && ",
+				// the commands run around the selected one are shown next to the list
+				PreviewMetacommand: "context",
+				Args: []*proto.MetacommandArgument{
+					{
+						Name:        "prefix",
+						Description: "only commands starting with prefix",
+						Dynamic:     true,
+					},
+				},
 			},
 			{
 				Name:   "last",
 				Format: proto.MetacommandResponseFormat_SHELL_INJECTION,
 			},
+			{
+				Name:        "show",
+				Format:      proto.MetacommandResponseFormat_TEXT,
+				Description: "show the history",
+				Args: []*proto.MetacommandArgument{
+					{
+						Name:        "limit",
+						Type:        proto.MetacommandArgumentType_INT,
+						Description: "only show the last limit commands",
+					},
+				},
+			},
+			{
+				Name:        "counts",
+				Format:      proto.MetacommandResponseFormat_ITEM_LIST,
+				Description: "count how often each command ran",
+				Args: []*proto.MetacommandArgument{
+					{
+						Name:        "sort",
+						Type:        proto.MetacommandArgumentType_ENUM,
+						EnumValues:  []string{"count", "name"},
+						Description: "order of the commands",
+					},
+				},
+			},
+			{
+				Name:   "top",
+				Format: proto.MetacommandResponseFormat_SCREEN,
+			},
+			{
+				Name:        "context",
+				Format:      proto.MetacommandResponseFormat_TEXT,
+				Description: "show the commands run around the last run of a command",
+				Args: []*proto.MetacommandArgument{
+					{
+						Name:     "command",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:        "recall",
+				Format:      proto.MetacommandResponseFormat_SHELL_REPLACE,
+				Description: "replace the command line with the last command starting with it",
+			},
+			{
+				Name:        "search",
+				Format:      proto.MetacommandResponseFormat_FORM,
+				Description: "search the history",
+			},
 		},
 	}, nil
 }
@@ -1,42 +1,22 @@
 package install
 
 import (
-	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/raphaelreyna/metashell/internal/config"
 	"github.com/raphaelreyna/metashell/internal/log"
+	"github.com/raphaelreyna/metashell/internal/shell"
 	"github.com/spf13/cobra"
 )
 
-const (
-	bashSource = `
-PROMPT_COMMAND=__postRun
-EXEC="%s shellclient"
-export METASHELL_CMD_KEY=INIT
-
-trap __preRun DEBUG
-
-__preRun() {
-	case "$BASH_COMMAND" in
-		$PROMPT_COMMAND)
-			;;
-		*)
-			TTY=$(tty)
-			METASHELL_CMD_KEY=$($EXEC --tty $TTY --cmd "$BASH_COMMAND")
-	esac
-}
-
-__postRun() {
-	$EXEC --cmdKey $METASHELL_CMD_KEY --exit-code $?
-}
-`
-)
-
 type Cmd struct {
 	command *cobra.Command
 	config  *config.Config
+
+	shell string
 }
 
 func New(config *config.Config) *Cmd {
@@ -59,12 +39,28 @@ func (c *Cmd) Cobra() *cobra.Command {
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			var (
+				dialect shell.Dialect
+				err     error
+			)
+			if c.shell != "" {
+				dialect, err = shell.Lookup(c.shell)
+			} else {
+				dialect, err = c.config.MetaShell.Dialect()
+			}
+			if err != nil {
+				return err
+			}
+
 			path := os.Args[0]
 			path, _ = filepath.EvalSymlinks(path)
-			_, err := fmt.Fprintf(os.Stdout, bashSource, path)
+			_, err = io.WriteString(os.Stdout, dialect.Hooks(path))
 			return err
 		},
 	}
 
+	fs := c.command.Flags()
+	fs.StringVar(&c.shell, "shell", "", "shell to install the hooks for ("+strings.Join(shell.Names(), ", ")+"); defaults to the configured shell")
+
 	return c.command
 }
@@ -1,9 +1,13 @@
 package shellclient
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/raphaelreyna/metashell/internal/config"
@@ -22,6 +26,7 @@ type Cmd struct {
 	cmd      string
 	cmdKey   string
 	exitCode int
+	serve    bool
 }
 
 func New(config *config.Config) *Cmd {
@@ -51,6 +56,7 @@ func (c *Cmd) Cobra() *cobra.Command {
 	fs.StringVar(&c.cmd, "cmd", "", "internal")
 	fs.StringVar(&c.cmdKey, "cmdKey", "", "internal")
 	fs.IntVar(&c.exitCode, "exit-code", -1, "internal")
+	fs.BoolVar(&c.serve, "serve", false, "internal")
 
 	return c.command
 }
@@ -66,24 +72,25 @@ func (sc *Cmd) Run(cmd *cobra.Command, _ []string) error {
 	)
 
 	switch {
+	case sc.serve && sc.tty != "":
+		logEvent.Info("ran", "mode", "serve")
+		return sc.runServer(ctx, os.Stdin)
 	case runPreRunQueryRequest && runRecordExitCode:
 		break
 	case runPreRunQueryRequest:
 		logEvent.Info("ran", "mode", "preRunQuery")
-		return sc.requestID(ctx)
+		return sc.withClient(ctx, func(ctx context.Context, client daemonproto.ShellclientDaemonClient) error {
+			return sc.requestID(ctx, client, os.Stdout)
+		})
 	case runRecordExitCode:
 		logEvent.Info("ran", "mode", "postRunReport")
-		return sc.recordExitCode(ctx)
+		return sc.withClient(ctx, sc.recordExitCode)
 	}
 
 	return fmt.Errorf("invalid flag combination")
 }
 
-func (r *Cmd) recordExitCode(ctx context.Context) error {
-	if r.exitCode < 0 {
-		panic("exit code not set")
-	}
-
+func (r *Cmd) withClient(ctx context.Context, f func(context.Context, daemonproto.ShellclientDaemonClient) error) error {
 	conn, err := grpc.Dial("unix://"+r.config.Daemon.SocketPath,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 	)
@@ -92,32 +99,93 @@ func (r *Cmd) recordExitCode(ctx context.Context) error {
 	}
 	defer conn.Close()
 
-	client := daemonproto.NewShellclientDaemonClient(conn)
-	_, err = client.PostRunReport(ctx, &daemonproto.PostRunReportRequest{
+	return f(ctx, daemonproto.NewShellclientDaemonClient(conn))
+}
+
+func (r *Cmd) recordExitCode(ctx context.Context, client daemonproto.ShellclientDaemonClient) error {
+	if r.exitCode < 0 {
+		panic("exit code not set")
+	}
+
+	_, err := client.PostRunReport(ctx, &daemonproto.PostRunReportRequest{
 		Uuid:     r.cmdKey,
 		ExitCode: int32(r.exitCode),
 	})
 	return err
 }
 
-func (r *Cmd) requestID(ctx context.Context) error {
-	conn, err := grpc.Dial("unix://"+r.config.Daemon.SocketPath,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
-	if err != nil {
-		return err
-	}
-	defer conn.Close()
-	client := daemonproto.NewShellclientDaemonClient(conn)
+func (r *Cmd) requestID(ctx context.Context, client daemonproto.ShellclientDaemonClient, out io.Writer) error {
 	resp, err := client.PreRunQuery(ctx, &daemonproto.PreRunQueryRequest{
 		Command:   r.cmd,
 		Tty:       r.tty,
 		Timestamp: time.Now().Unix(),
+		Key:       r.cmdKey,
 	})
 	if err != nil {
 		return err
 	}
 
-	fmt.Fprint(os.Stdout, resp.Uuid)
+	fmt.Fprint(out, resp.Uuid)
 	return err
 }
+
+// runServer runs the shellclient as a co-process of the shell, serving the
+// hooks over a line based protocol for the lifetime of the shell so that
+// the hooks neither fork nor dial the daemon for every command:
+//
+//	pre <command key> <command>
+//	post <command key> <exit code>
+//
+// Backslashes and newlines in the command are escaped as \\ and \n.
+func (r *Cmd) runServer(ctx context.Context, in io.Reader) error {
+	return r.withClient(ctx, func(ctx context.Context, client daemonproto.ShellclientDaemonClient) error {
+		scanner := bufio.NewScanner(in)
+		scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1<<20)
+		for scanner.Scan() {
+			verb, rest, _ := strings.Cut(scanner.Text(), " ")
+			switch verb {
+			case "pre":
+				r.cmdKey, rest, _ = strings.Cut(rest, " ")
+				r.cmd = unescapeCommand(rest)
+				if err := r.requestID(ctx, client, io.Discard); err != nil {
+					log.Error("error querying daemon", err)
+				}
+			case "post":
+				var err error
+				r.cmdKey, rest, _ = strings.Cut(rest, " ")
+				if r.exitCode, err = strconv.Atoi(rest); err != nil {
+					log.Error("invalid exit code", err, "line", scanner.Text())
+					continue
+				}
+				if err := r.recordExitCode(ctx, client); err != nil {
+					log.Error("error reporting exit code to daemon", err)
+				}
+			default:
+				log.Warn("unknown request", "line", scanner.Text())
+			}
+		}
+		return scanner.Err()
+	})
+}
+
+func unescapeCommand(s string) string {
+	var (
+		sb      strings.Builder
+		escaped bool
+	)
+	for _, c := range s {
+		switch {
+		case escaped && c == 'n':
+			sb.WriteRune('\n')
+		case escaped:
+			sb.WriteRune(c)
+		case c == '\\':
+			escaped = true
+			continue
+		default:
+			sb.WriteRune(c)
+		}
+		escaped = false
+	}
+	return sb.String()
+}
@@ -7,6 +7,7 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"slices"
 	"strings"
 	"syscall"
 
@@ -16,7 +17,9 @@ import (
 	"github.com/raphaelreyna/metashell/pkg/plugin/proto/proto"
 	godaemon "github.com/sevlyar/go-daemon"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const SubCommandDaemon = "daemon"
@@ -194,29 +197,33 @@ func (d *Daemon) NewExitCodeStream(_ *daemonproto.Empty, server daemonproto.Meta
 func (d *Daemon) RegisterCommandEntry(ctx context.Context, req *daemonproto.CommandEntry) (*daemonproto.CommandKey, error) {
 	log.Debug("RegisterCommandEntry")
 
-	key := d.cks.registerVector(&vector{
+	if req.Key == "" {
+		return nil, fmt.Errorf("no command key given")
+	}
+
+	d.cks.registerVector(req.Key, &vector{
 		command:   req.Command,
 		tty:       req.Tty,
 		timestamp: req.Timestamp,
 	})
 
-	return &daemonproto.CommandKey{Key: key}, nil
+	return &daemonproto.CommandKey{Key: req.Key}, nil
 }
 
 func (d *Daemon) PreRunQuery(ctx context.Context, req *daemonproto.PreRunQueryRequest) (*daemonproto.PreRunQueryResponse, error) {
 	log.Debug("PreRunQuery")
 
-	k := d.cks.getKey(&vector{
-		command:   req.Command,
-		tty:       req.Tty,
-		timestamp: req.Timestamp,
-	})
-
-	if k == "" {
-		k = "INIT"
+	if req.Key == "" || req.Key == "INIT" {
+		return &daemonproto.PreRunQueryResponse{Uuid: "INIT"}, nil
 	}
 
-	return &daemonproto.PreRunQueryResponse{Uuid: k}, nil
+	d.cks.registerVector(req.Key, &vector{
+		shellCommand: req.Command,
+		tty:          req.Tty,
+		timestamp:    req.Timestamp,
+	})
+
+	return &daemonproto.PreRunQueryResponse{Uuid: req.Key}, nil
 }
 
 func (d *Daemon) PostRunReport(ctx context.Context, req *daemonproto.PostRunReportRequest) (*daemonproto.Empty, error) {
@@ -250,10 +257,17 @@ func (d *Daemon) PostRunReport(ctx context.Context, req *daemonproto.PostRunRepo
 		}
 	}()
 
+	if v.command != "" && v.shellCommand != "" && v.command != v.shellCommand {
+		log.Debug("typed command differs from the command run by the shell",
+			"typed", v.command,
+			"command", v.shellCommand,
+		)
+	}
+
 	go func() {
 		log.Debug("sending command report to plugins")
 		d.plugins.CommandReport(context.TODO(), &proto.ReportCommandRequest{
-			Command:   v.command,
+			Command:   v.reportedCommand(),
 			Tty:       v.tty,
 			Timestamp: uint64(v.timestamp),
 			ExitCode:  req.ExitCode,
@@ -267,30 +281,100 @@ func (d *Daemon) PostRunReport(ctx context.Context, req *daemonproto.PostRunRepo
 func (d *Daemon) Metacommand(ctx context.Context, req *daemonproto.MetacommandRequest) (*daemonproto.MetacommandResponse, error) {
 	log.Info("Metacommand")
 
-	resp1, err := d.plugins.Metacommand(ctx, req.PluginName, req.MetaCommand, req.Args)
+	resp1, err := d.plugins.Metacommand(ctx, req.PluginName, &proto.MetacommandRequest{
+		MetaCommand: req.MetaCommand,
+		Args:        req.Args,
+		FormatArgs:  req.FormatArgs,
+		Tty:         req.Tty,
+		CompleteArg: req.CompleteArg,
+		FormValues:  req.FormValues,
+		CommandLine: req.CommandLine,
+	})
 	resp2 := &daemonproto.MetacommandResponse{}
 	if err != nil {
+		if ctx.Err() != nil {
+			log.Info("metacommand canceled",
+				"plugin", req.PluginName,
+				"metacommand", req.MetaCommand,
+				"reason", ctx.Err(),
+			)
+		}
 		resp2.Error = err.Error()
 	}
 	if resp1 != nil {
 		resp2.Data = resp1.Data
+		if resp2.Error == "" {
+			resp2.Error = resp1.Error
+		}
 	}
 
 	return resp2, err
 }
 
+// MetacommandPipeline runs the stages of a pipeline in turn, appending the
+// data returned by each stage to the arguments of the next. It stops early at
+// a stage returning a list, for the caller to pick the input of the next
+// stage from and run the rest of the pipeline.
+func (d *Daemon) MetacommandPipeline(ctx context.Context, req *daemonproto.MetacommandPipelineRequest) (*daemonproto.MetacommandPipelineResponse, error) {
+	log.Info("MetacommandPipeline")
+
+	if len(req.Stages) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty pipeline")
+	}
+
+	var (
+		resp  = &daemonproto.MetacommandPipelineResponse{}
+		input []byte
+	)
+	for idx, stage := range req.Stages {
+		name := stage.PluginName + "::" + stage.MetaCommand
+		info := d.plugins.GetMetacommandInfo(stage.PluginName, stage.MetaCommand)
+		if info == nil {
+			return nil, status.Errorf(codes.NotFound, "unknown metacommand %s", name)
+		}
+
+		stageReq := &daemonproto.MetacommandRequest{
+			PluginName:  stage.PluginName,
+			MetaCommand: stage.MetaCommand,
+			Args:        stage.Args,
+			FormatArgs:  stage.FormatArgs,
+			Tty:         stage.Tty,
+			FormValues:  stage.FormValues,
+			CommandLine: stage.CommandLine,
+		}
+		if 0 < idx {
+			stageReq.Args = append(slices.Clip(stageReq.Args), string(input))
+		}
+
+		stageResp, err := d.Metacommand(ctx, stageReq)
+		if err != nil {
+			s := status.Convert(err)
+			return nil, status.Errorf(s.Code(), "%s: %s", name, s.Message())
+		}
+		if msg := stageResp.GetError(); msg != "" {
+			return nil, status.Errorf(codes.Unknown, "%s: %s", name, msg)
+		}
+
+		resp.Stage, resp.Request, resp.Response = int32(idx), stageReq, stageResp
+		if info.Format == proto.MetacommandResponseFormat_ITEM_LIST ||
+			info.Format == proto.MetacommandResponseFormat_SHELL_INJECTION_LIST {
+			break
+		}
+		input = stageResp.Data
+	}
+
+	return resp, nil
+}
+
 func (d *Daemon) GetPluginInfo(ctx context.Context, req *daemonproto.GetPluginInfoRequest) (*daemonproto.GetPluginInfoResponse, error) {
 	var plugins = make([]*daemonproto.PluginInfo, 0)
 
 	for _, info := range d.plugins.GetMetacommandPluginInfoMatches(req.PluginName) {
 		var mcs = make([]*daemonproto.MetacommandInfo, 0)
 
-		for mcName, mcFormat := range info.MetaCommands {
+		for mcName, mc := range info.MetaCommands {
 			if strings.HasPrefix(mcName, req.MetacommandName) {
-				mcs = append(mcs, &daemonproto.MetacommandInfo{
-					Name:   mcName,
-					Format: daemonproto.MetacommandResponseFormat(mcFormat),
-				})
+				mcs = append(mcs, metacommandInfo(mc))
 			}
 		}
 
@@ -307,3 +391,27 @@ func (d *Daemon) GetPluginInfo(ctx context.Context, req *daemonproto.GetPluginIn
 		Plugins: plugins,
 	}, nil
 }
+
+func metacommandInfo(mc *proto.MetacommandInfo) *daemonproto.MetacommandInfo {
+	info := daemonproto.MetacommandInfo{
+		Name:               mc.Name,
+		Format:             daemonproto.MetacommandResponseFormat(mc.Format),
+		Description:        mc.Description,
+		Args:               make([]*daemonproto.MetacommandArgument, len(mc.Args)),
+		MultiSelect:        mc.MultiSelect,
+		Separator:          mc.Separator,
+		PreviewMetacommand: mc.PreviewMetacommand,
+	}
+	for idx, arg := range mc.Args {
+		info.Args[idx] = &daemonproto.MetacommandArgument{
+			Name:        arg.Name,
+			Type:        daemonproto.MetacommandArgumentType(arg.Type),
+			Required:    arg.Required,
+			EnumValues:  arg.EnumValues,
+			Description: arg.Description,
+			Dynamic:     arg.Dynamic,
+		}
+	}
+
+	return &info
+}
@@ -1,140 +1,92 @@
 package daemon
 
 import (
-	"crypto/md5"
-	"fmt"
-	"math"
+	"slices"
 	"sync"
-
-	"github.com/agnivade/levenshtein"
 )
 
 type vector struct {
 	tty       string
-	command   string
 	timestamp int64
+	// command is the command line typed into metashell, as reconstructed
+	// from the keystrokes it saw.
+	command string
+	// shellCommand is the command line the shell actually ran.
+	shellCommand string
 }
 
-func vectorMetric(v1, v2 *vector) float64 {
-	if v1.tty != v2.tty {
-		return math.MaxFloat64
-	}
-
-	dt := v1.timestamp - v2.timestamp
-	if dt < 0 {
-		dt *= -1
+// reportedCommand returns the command line the shell ran, falling back to
+// the keystrokes typed into metashell if the shell did not report one.
+func (v *vector) reportedCommand() string {
+	if v.shellCommand != "" {
+		return v.shellCommand
 	}
-
-	dc := int64(levenshtein.ComputeDistance(v1.command, v2.command))
-
-	return math.Sqrt(float64(dt*dt + dc*dc))
-}
-
-func (v *vector) key() string {
-	h := md5.Sum([]byte(v.tty + v.command))
-	return fmt.Sprintf("%d-%x", v.timestamp, h)
+	return v.command
 }
 
+// cmdKeyService correlates the command entries registered by metashell with
+// the lifecycle reports sent by the shell hooks. Both sides share the key of
+// each command (the metashell session nonce and the hooks' command sequence
+// number), so entries are matched exactly.
 type cmdKeyService struct {
-	assignedKeys map[string]*vector
-	timeSeries   []*vector
-	sync.RWMutex
+	entries map[string]*vector
+	// exchanged holds the latest keys whose lifecycle is over, oldest
+	// first, so that registrations arriving late for them are dropped
+	// rather than kept forever.
+	exchanged []string
+	sync.Mutex
 }
 
-func (cks *cmdKeyService) registerVector(v *vector) string {
+// maxExchangedKeys is the number of exchanged keys remembered.
+const maxExchangedKeys = 256
+
+// registerVector records v under key, merging it with whatever the other
+// side already registered for the same key.
+func (cks *cmdKeyService) registerVector(key string, v *vector) {
 	cks.Lock()
 	defer cks.Unlock()
 
-	if cks.assignedKeys == nil {
-		cks.assignedKeys = map[string]*vector{}
+	if cks.entries == nil {
+		cks.entries = map[string]*vector{}
 	}
-	if cks.timeSeries == nil {
-		cks.timeSeries = []*vector{}
+	if slices.Contains(cks.exchanged, key) {
+		return
 	}
 
-	key := v.key()
-	cks.assignedKeys[key] = v
-	cks.timeSeries = append(cks.timeSeries, v)
-
-	return key
-}
-
-func (cks *cmdKeyService) getKey(v *vector) string {
-	cks.Lock()
-	defer cks.Unlock()
-
-	if cks.assignedKeys == nil || cks.timeSeries == nil {
-		return ""
+	vv, ok := cks.entries[key]
+	if !ok {
+		cks.entries[key] = v
+		return
 	}
 
-	// find the closest match
-	var (
-		minv *vector
-		min  = math.MaxFloat64
-	)
-	for idx := len(cks.timeSeries) - 1; 0 <= idx; idx-- {
-		vv := cks.timeSeries[idx]
-
-		d := vectorMetric(v, vv)
-		switch {
-		case d < min:
-			min = d
-			minv = vv
-		case d == min:
-			// use time as the tiebreaker
-			dt := vv.timestamp - v.timestamp
-			if dt < 0 {
-				dt *= -1
-			}
-
-			minDt := minv.timestamp - v.timestamp
-			if minDt < 0 {
-				minDt *= -1
-			}
-
-			if dt < minDt {
-				min = d
-				minv = vv
-			}
-		}
+	if vv.command == "" {
+		vv.command = v.command
 	}
-
-	// find the key
-	var key string
-	for k, v := range cks.assignedKeys {
-		if v == minv {
-			key = k
-			break
-		}
+	if vv.shellCommand == "" {
+		vv.shellCommand = v.shellCommand
+	}
+	if vv.tty == "" {
+		vv.tty = v.tty
+	}
+	if vv.timestamp == 0 {
+		vv.timestamp = v.timestamp
 	}
-
-	return key
 }
 
 func (cks *cmdKeyService) exchangeKey(k string) *vector {
 	cks.Lock()
 	defer cks.Unlock()
 
-	if len(cks.assignedKeys) == 0 || len(cks.timeSeries) == 0 {
-		return nil
+	if len(cks.exchanged) == maxExchangedKeys {
+		cks.exchanged = cks.exchanged[1:]
 	}
+	cks.exchanged = append(cks.exchanged, k)
 
-	v, ok := cks.assignedKeys[k]
+	v, ok := cks.entries[k]
 	if !ok {
 		return nil
 	}
-
-	delete(cks.assignedKeys, k)
-	idx := -1
-	for i := 0; i < len(cks.timeSeries); i++ {
-		if cks.timeSeries[i] == v {
-			idx = i
-			break
-		}
-	}
-	if 0 <= idx {
-		cks.timeSeries = append(cks.timeSeries[:idx], cks.timeSeries[idx+1:]...)
-	}
+	delete(cks.entries, k)
 
 	return v
 }
@@ -0,0 +1,92 @@
+package daemon
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestCmdKeyService(t *testing.T) {
+	var (
+		metashellSide = &vector{tty: "/dev/pts/1", timestamp: 42, command: "ls -l"}
+		shellSide     = &vector{shellCommand: "ls -l --color"}
+		merged        = &vector{tty: "/dev/pts/1", timestamp: 42, command: "ls -l", shellCommand: "ls -l --color"}
+	)
+
+	tests := []struct {
+		name          string
+		registrations []*vector
+		// late are registered after the key was exchanged.
+		late []*vector
+		want *vector
+	}{
+		{
+			name:          "metashell first",
+			registrations: []*vector{metashellSide, shellSide},
+			want:          merged,
+		},
+		{
+			name:          "shell first",
+			registrations: []*vector{shellSide, metashellSide},
+			want:          merged,
+		},
+		{
+			name:          "one side only",
+			registrations: []*vector{shellSide},
+			want:          shellSide,
+		},
+		{
+			name:          "late registration",
+			registrations: []*vector{shellSide},
+			late:          []*vector{metashellSide},
+			want:          shellSide,
+		},
+		{
+			name: "registration after an exchange of an unknown key",
+			late: []*vector{metashellSide},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cks cmdKeyService
+			for _, v := range tt.registrations {
+				// a copy, the service merging into the first vector registered
+				v := *v
+				cks.registerVector("key", &v)
+			}
+
+			if got := cks.exchangeKey("key"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("exchangeKey() = %+v, want %+v", got, tt.want)
+			}
+
+			for _, v := range tt.late {
+				v := *v
+				cks.registerVector("key", &v)
+			}
+			if len(cks.entries) != 0 {
+				t.Errorf("entries left after the exchange: %+v", cks.entries)
+			}
+			if got := cks.exchangeKey("key"); got != nil {
+				t.Errorf("second exchangeKey() = %+v, want nil", got)
+			}
+		})
+	}
+}
+
+func TestCmdKeyServiceForgetsOldKeys(t *testing.T) {
+	var cks cmdKeyService
+	cks.exchangeKey("old")
+	for i := range maxExchangedKeys {
+		cks.exchangeKey(fmt.Sprint(i))
+	}
+
+	// too old to be told apart from a new command
+	cks.registerVector("old", &vector{command: "ls"})
+	if _, ok := cks.entries["old"]; !ok {
+		t.Errorf("registration for a forgotten key was dropped")
+	}
+	if len(cks.exchanged) != maxExchangedKeys {
+		t.Errorf("%d exchanged keys remembered, want %d", len(cks.exchanged), maxExchangedKeys)
+	}
+}
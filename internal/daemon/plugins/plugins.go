@@ -18,7 +18,7 @@ import (
 type PluginInfo struct {
 	Name           string
 	AcceptsReports bool
-	MetaCommands   map[string]int
+	MetaCommands   map[string]*proto.MetacommandInfo
 }
 
 type Plugins struct {
@@ -40,7 +40,6 @@ func (p *Plugins) GetPluginInfoMatches(pluginName string) []PluginInfo {
 	for pn, pi := range p.info {
 		if strings.HasPrefix(pn, pluginName) {
 			infos = append(infos, pi)
-			break
 		}
 	}
 
@@ -57,13 +56,18 @@ func (p *Plugins) GetMetacommandPluginInfoMatches(pluginName string) []PluginInf
 	for pn, pi := range p.info {
 		if strings.HasPrefix(pn, pluginName) && 0 < len(pi.MetaCommands) {
 			infos = append(infos, pi)
-			break
 		}
 	}
 
 	return infos
 }
 
+// GetMetacommandInfo returns the info of the metacommand mcName of the
+// plugin pluginName, or nil if there is no such metacommand.
+func (p *Plugins) GetMetacommandInfo(pluginName, mcName string) *proto.MetacommandInfo {
+	return p.info[pluginName].MetaCommands[mcName]
+}
+
 func (p *Plugins) Reload(ctx context.Context) error {
 	err := p.Close()
 	if err != nil {
@@ -167,10 +171,10 @@ func (p *Plugins) Reload(ctx context.Context) error {
 		pi := PluginInfo{
 			Name:           info.Name,
 			AcceptsReports: info.AcceptsCommandReports,
-			MetaCommands:   make(map[string]int),
+			MetaCommands:   make(map[string]*proto.MetacommandInfo),
 		}
 		for _, mc := range info.Metacommands {
-			pi.MetaCommands[mc.Name] = int(mc.Format)
+			pi.MetaCommands[mc.Name] = mc
 		}
 
 		p.clients = append(p.clients, client)
@@ -206,16 +210,13 @@ func (p *Plugins) CommandReport(ctx context.Context, rep *proto.ReportCommandReq
 	return nil
 }
 
-func (p *Plugins) Metacommand(ctx context.Context, pluginName, cmd string, args []string) (*proto.MetacommandResponse, error) {
+func (p *Plugins) Metacommand(ctx context.Context, pluginName string, req *proto.MetacommandRequest) (*proto.MetacommandResponse, error) {
 	h := p.daemonPlugins[pluginName]
 	if h == nil {
 		return nil, fmt.Errorf("plugin %s not found", pluginName)
 	}
 
-	return h.Metacommand(ctx, &proto.MetacommandRequest{
-		MetaCommand: cmd,
-		Args:        args,
-	})
+	return h.Metacommand(ctx, req)
 }
 
 func (p *Plugins) Close() error {
@@ -1,10 +1,35 @@
 package metashell
 
-import "path/filepath"
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/raphaelreyna/metashell/internal/metashell/metamode"
+	"github.com/raphaelreyna/metashell/internal/shell"
+)
+
+const (
+	// DelineationHooks delineates commands with hooks installed into the shell.
+	DelineationHooks = "hooks"
+	// DelineationOSC133 delineates commands with the OSC 133 semantic prompt
+	// markers emitted by the shell or its prompt framework.
+	DelineationOSC133 = "osc133"
+)
 
 type Config struct {
 	ShellPath  string
 	PluginsDir string
+	// Shell overrides the shell dialect, which is otherwise
+	// picked from the basename of ShellPath.
+	Shell string `yaml:"shell"`
+	// Delineation selects how command boundaries are detected.
+	Delineation string `yaml:"delineation"`
+	// Leader is the key chord that enters metamode, e.g. "esc",
+	// "ctrl-space" or "esc esc".
+	Leader string `yaml:"leader"`
+	// LeaderTimeout is how long to wait for the next key of the leader chord.
+	LeaderTimeout time.Duration   `yaml:"leader_timeout"`
+	Metamode      metamode.Config `yaml:"metamode"`
 
 	socketPath string
 }
@@ -20,5 +45,22 @@ func (c *Config) SetDefaults(rootDir string) {
 	if c.PluginsDir == "" {
 		c.PluginsDir = filepath.Join(rootDir, "plugins", "metashell")
 	}
+	if c.Delineation == "" {
+		c.Delineation = DelineationHooks
+	}
+	if c.Leader == "" {
+		c.Leader = "esc"
+	}
+	if c.LeaderTimeout == 0 {
+		c.LeaderTimeout = 500 * time.Millisecond
+	}
+	c.Metamode.SetDefaults(rootDir)
 	c.socketPath = filepath.Join(rootDir, "daemon.socket")
 }
+
+func (c Config) Dialect() (shell.Dialect, error) {
+	if c.Shell != "" {
+		return shell.Lookup(c.Shell)
+	}
+	return shell.FromPath(c.ShellPath)
+}
@@ -0,0 +1,257 @@
+package metashell
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/sys/unix"
+)
+
+// escTimeout is how long to wait for the rest of an escape sequence before
+// taking an ESC byte to be the escape key on its own.
+const escTimeout = 25 * time.Millisecond
+
+var (
+	errInputTimeout = errors.New("timed out waiting for input")
+
+	pasteStart = []byte("\x1b[200~")
+	pasteEnd   = []byte("\x1b[201~")
+)
+
+const (
+	bracketedPasteOn  = "\x1b[?2004h"
+	bracketedPasteOff = "\x1b[?2004l"
+)
+
+type tokenKind int
+
+const (
+	tokenText    tokenKind = iota // a UTF-8 encoded character
+	tokenControl                  // a C0 control character or DEL
+	tokenEsc                      // the escape key on its own
+	tokenAlt                      // ESC followed by a character
+	tokenCSI                      // a control sequence
+	tokenSS3                      // a single shift 3 sequence
+	tokenPaste                    // a bracketed paste
+)
+
+// token is a single key press, escape sequence or paste read from the terminal.
+type token struct {
+	kind tokenKind
+	// raw holds the bytes as they were read from the terminal.
+	raw []byte
+}
+
+// text returns the text the token inserts into the command line.
+func (t token) text() string {
+	switch t.kind {
+	case tokenText:
+		return string(t.raw)
+	case tokenPaste:
+		return string(t.raw[len(pasteStart) : len(t.raw)-len(pasteEnd)])
+	}
+	return ""
+}
+
+// inputDecoder splits the terminal input into tokens. It only reads from
+// the terminal while asked for a token, leaving it free for metamode.
+type inputDecoder struct {
+	in  *os.File
+	buf []byte
+}
+
+// next returns the next token, waiting at most timeout for it if timeout is
+// positive.
+func (d *inputDecoder) next(timeout time.Duration) (token, error) {
+	var deadline time.Time
+	if 0 < timeout {
+		deadline = time.Now().Add(timeout)
+	}
+
+	for {
+		tok, n, complete := decodeToken(d.buf)
+		if 0 < n && complete {
+			d.buf = d.buf[n:]
+			return tok, nil
+		}
+
+		wait := time.Duration(-1)
+		if 0 < n {
+			// an incomplete escape sequence; a lone ESC is the escape key
+			wait = escTimeout
+			if bytes.HasPrefix(d.buf, pasteStart) {
+				wait = -1
+			}
+		}
+		if !deadline.IsZero() {
+			left := time.Until(deadline)
+			if left <= 0 {
+				return token{}, errInputTimeout
+			}
+			if wait < 0 || left < wait {
+				wait = left
+			}
+		}
+
+		ok, err := d.fill(wait)
+		switch {
+		case err != nil:
+			return token{}, err
+		case !ok && 0 < n && (deadline.IsZero() || time.Now().Before(deadline)):
+			switch {
+			case d.buf[0] != 0x1b:
+				tok = token{kind: tokenText, raw: d.buf[:n]}
+			case n == 1:
+				tok = token{kind: tokenEsc, raw: d.buf[:n]}
+			default:
+				tok = token{kind: tokenAlt, raw: d.buf[:n]}
+			}
+			d.buf = d.buf[n:]
+			return tok, nil
+		}
+	}
+}
+
+// fill reads more input, waiting at most wait for it if wait is not negative.
+// It reports whether any input was read.
+func (d *inputDecoder) fill(wait time.Duration) (bool, error) {
+	if 0 <= wait {
+		var (
+			fds      = []unix.PollFd{{Fd: int32(d.in.Fd()), Events: unix.POLLIN}}
+			deadline = time.Now().Add(wait)
+		)
+		for {
+			n, err := unix.Poll(fds, int(max(time.Until(deadline), 0).Milliseconds()))
+			if errors.Is(err, unix.EINTR) {
+				// interrupted by a signal, e.g. SIGWINCH on resize
+				continue
+			}
+			if err != nil {
+				return false, err
+			}
+			if n == 0 {
+				return false, nil
+			}
+			break
+		}
+	}
+
+	var p [4096]byte
+	n, err := d.in.Read(p[:])
+	d.buf = append(d.buf, p[:n]...)
+
+	return 0 < n, err
+}
+
+// decodeToken decodes the token at the start of b, returning it along with
+// its length. If b only holds the start of a token, complete is false and n
+// is the length of what there is of it.
+func decodeToken(b []byte) (tok token, n int, complete bool) {
+	if len(b) == 0 {
+		return token{}, 0, false
+	}
+
+	switch c := b[0]; {
+	case c == 0x1b:
+		return decodeEscape(b)
+	case c < 0x20 || c == 0x7f:
+		return token{kind: tokenControl, raw: b[:1]}, 1, true
+	case !utf8.FullRune(b):
+		return token{}, len(b), false
+	default:
+		_, n = utf8.DecodeRune(b)
+		return token{kind: tokenText, raw: b[:n]}, n, true
+	}
+}
+
+func decodeEscape(b []byte) (token, int, bool) {
+	if len(b) < 2 {
+		return token{}, len(b), false
+	}
+
+	switch b[1] {
+	case '[':
+		if bytes.HasPrefix(b, pasteStart) {
+			end := bytes.Index(b, pasteEnd)
+			if end < 0 {
+				return token{}, len(b), false
+			}
+			n := end + len(pasteEnd)
+			return token{kind: tokenPaste, raw: b[:n]}, n, true
+		}
+		if len(pasteStart) > len(b) && bytes.HasPrefix(pasteStart, b) {
+			return token{}, len(b), false
+		}
+		for i := 2; i < len(b); i++ {
+			// parameter and intermediate bytes run up to the final byte
+			if 0x40 <= b[i] && b[i] <= 0x7e {
+				return token{kind: tokenCSI, raw: b[:i+1]}, i + 1, true
+			}
+			if b[i] < 0x20 || 0x7e < b[i] {
+				return token{kind: tokenAlt, raw: b[:2]}, 2, true
+			}
+		}
+		return token{}, len(b), false
+	case 'O':
+		if len(b) < 3 {
+			return token{}, len(b), false
+		}
+		return token{kind: tokenSS3, raw: b[:3]}, 3, true
+	case 0x1b:
+		return token{kind: tokenEsc, raw: b[:1]}, 1, true
+	}
+
+	if !utf8.FullRune(b[1:]) {
+		return token{}, len(b), false
+	}
+	_, n := utf8.DecodeRune(b[1:])
+	return token{kind: tokenAlt, raw: b[:1+n]}, 1 + n, true
+}
+
+// parseLeader parses a chord such as "esc", "ctrl-space" or "esc esc" into
+// the bytes each of its keys sends.
+func parseLeader(leader string) ([][]byte, error) {
+	var keys [][]byte
+	for _, name := range strings.Fields(strings.ToLower(leader)) {
+		key, err := parseKey(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid leader %q: %w", leader, err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("empty leader")
+	}
+	return keys, nil
+}
+
+func parseKey(name string) ([]byte, error) {
+	switch name {
+	case "esc", "escape":
+		return []byte{0x1b}, nil
+	case "tab":
+		return []byte{'\t'}, nil
+	case "ctrl-space", "ctrl-@":
+		return []byte{0}, nil
+	}
+
+	if c, ok := strings.CutPrefix(name, "ctrl-"); ok && len(c) == 1 {
+		switch {
+		case 'a' <= c[0] && c[0] <= 'z':
+			return []byte{c[0] - 'a' + 1}, nil
+		case '[' <= c[0] && c[0] <= '_':
+			return []byte{c[0] - '@'}, nil
+		}
+	}
+
+	if c, ok := strings.CutPrefix(name, "alt-"); ok && utf8.RuneCountInString(c) == 1 {
+		return append([]byte{0x1b}, c...), nil
+	}
+
+	return nil, fmt.Errorf("unknown key %q", name)
+}
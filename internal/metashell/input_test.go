@@ -0,0 +1,215 @@
+package metashell
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// decodeAll decodes the complete tokens at the start of b, returning them
+// along with the incomplete rest.
+func decodeAll(b []byte) ([]token, []byte) {
+	var toks []token
+	for {
+		tok, n, complete := decodeToken(b)
+		if n == 0 || !complete {
+			return toks, b
+		}
+		toks = append(toks, tok)
+		b = b[n:]
+	}
+}
+
+func TestDecodeToken(t *testing.T) {
+	tok := func(kind tokenKind, raw string) token {
+		return token{kind: kind, raw: []byte(raw)}
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want []token
+		// rest is what is left waiting for more input,
+		// a lone ESC only being told apart by a timeout.
+		rest string
+	}{
+		{
+			name: "text",
+			in:   "ls",
+			want: []token{tok(tokenText, "l"), tok(tokenText, "s")},
+		},
+		{
+			name: "utf-8",
+			in:   "é€😀",
+			want: []token{tok(tokenText, "é"), tok(tokenText, "€"), tok(tokenText, "😀")},
+		},
+		{
+			name: "controls",
+			in:   "\r\x7f\x03",
+			want: []token{tok(tokenControl, "\r"), tok(tokenControl, "\x7f"), tok(tokenControl, "\x03")},
+		},
+		{
+			name: "csi",
+			in:   "\x1b[A\x1b[1;5C\x1b[3~",
+			want: []token{tok(tokenCSI, "\x1b[A"), tok(tokenCSI, "\x1b[1;5C"), tok(tokenCSI, "\x1b[3~")},
+		},
+		{
+			name: "ss3",
+			in:   "\x1bOP\x1bOA",
+			want: []token{tok(tokenSS3, "\x1bOP"), tok(tokenSS3, "\x1bOA")},
+		},
+		{
+			name: "alt",
+			in:   "\x1bx\x1bé",
+			want: []token{tok(tokenAlt, "\x1bx"), tok(tokenAlt, "\x1bé")},
+		},
+		{
+			name: "csi cut short by a control",
+			in:   "\x1b[\x03",
+			want: []token{tok(tokenAlt, "\x1b["), tok(tokenControl, "\x03")},
+		},
+		{
+			name: "esc esc",
+			in:   "\x1b\x1b",
+			want: []token{tok(tokenEsc, "\x1b")},
+			rest: "\x1b",
+		},
+		{
+			name: "esc before a csi",
+			in:   "\x1b\x1b[B",
+			want: []token{tok(tokenEsc, "\x1b"), tok(tokenCSI, "\x1b[B")},
+		},
+		{
+			name: "paste",
+			in:   "\x1b[200~echo hi\r\x1b[201~x",
+			want: []token{tok(tokenPaste, "\x1b[200~echo hi\r\x1b[201~"), tok(tokenText, "x")},
+		},
+		{
+			name: "paste containing esc",
+			in:   "\x1b[200~a\x1b[Ab\x1b\x1bc\x1b[201~",
+			want: []token{tok(tokenPaste, "\x1b[200~a\x1b[Ab\x1b\x1bc\x1b[201~")},
+		},
+		{
+			name: "incomplete paste",
+			in:   "\x1b[200~abc\x1b[201",
+			rest: "\x1b[200~abc\x1b[201",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toks, rest := decodeAll([]byte(tt.in))
+			if !reflect.DeepEqual(toks, tt.want) || string(rest) != tt.rest {
+				t.Fatalf("decoding %q = %q, rest %q; want %q, rest %q", tt.in, toks, rest, tt.want, tt.rest)
+			}
+
+			// the same tokens whichever byte the input is split at across reads
+			for i := 1; i < len(tt.in); i++ {
+				toks, rest := decodeAll([]byte(tt.in[:i]))
+				more, rest := decodeAll(append(rest, tt.in[i:]...))
+				toks = append(toks, more...)
+				if !reflect.DeepEqual(toks, tt.want) || string(rest) != tt.rest {
+					t.Errorf("decoding %q split at %d = %q, rest %q; want %q, rest %q", tt.in, i, toks, rest, tt.want, tt.rest)
+				}
+			}
+		})
+	}
+}
+
+func TestInputDecoderNext(t *testing.T) {
+	tests := []struct {
+		name string
+		// reads are written to the terminal one at a time, a
+		// fraction of escTimeout apart.
+		reads []string
+		want  []token
+	}{
+		{
+			name:  "csi split across reads",
+			reads: []string{"\x1b", "[", "1;5", "C"},
+			want:  []token{{kind: tokenCSI, raw: []byte("\x1b[1;5C")}},
+		},
+		{
+			name:  "utf-8 split across reads",
+			reads: []string{"\xf0\x9f", "\x98\x80"},
+			want:  []token{{kind: tokenText, raw: []byte("😀")}},
+		},
+		{
+			name:  "lone esc",
+			reads: []string{"\x1b"},
+			want:  []token{{kind: tokenEsc, raw: []byte("\x1b")}},
+		},
+		{
+			name:  "esc esc",
+			reads: []string{"\x1b\x1b"},
+			want:  []token{{kind: tokenEsc, raw: []byte("\x1b")}, {kind: tokenEsc, raw: []byte("\x1b")}},
+		},
+		{
+			name:  "alt split across reads",
+			reads: []string{"\x1b", "x"},
+			want:  []token{{kind: tokenAlt, raw: []byte("\x1bx")}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			defer w.Close()
+
+			go func() {
+				for _, s := range tt.reads {
+					w.WriteString(s)
+					time.Sleep(escTimeout / 5)
+				}
+			}()
+
+			d := inputDecoder{in: r}
+			for _, want := range tt.want {
+				got, err := d.next(time.Second)
+				if err != nil {
+					t.Fatalf("next() error: %v", err)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("next() = %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseLeader(t *testing.T) {
+	tests := []struct {
+		leader  string
+		want    [][]byte
+		wantErr bool
+	}{
+		{leader: "esc", want: [][]byte{{0x1b}}},
+		{leader: "Esc Esc", want: [][]byte{{0x1b}, {0x1b}}},
+		{leader: "ctrl-space", want: [][]byte{{0}}},
+		{leader: "ctrl-a", want: [][]byte{{1}}},
+		{leader: "ctrl-]", want: [][]byte{{0x1d}}},
+		{leader: "ctrl-x ctrl-x", want: [][]byte{{0x18}, {0x18}}},
+		{leader: "alt-m", want: [][]byte{[]byte("\x1bm")}},
+		{leader: "tab", want: [][]byte{{'\t'}}},
+		{leader: "", wantErr: true},
+		{leader: "ctrl-ab", wantErr: true},
+		{leader: "alt-", wantErr: true},
+		{leader: "hyper-x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseLeader(tt.leader)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseLeader(%q) error = %v, wantErr %v", tt.leader, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseLeader(%q) = %q, want %q", tt.leader, got, tt.want)
+		}
+	}
+}
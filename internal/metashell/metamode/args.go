@@ -0,0 +1,192 @@
+package metamode
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	daemonproto "github.com/raphaelreyna/metashell/internal/rpc/go/daemon"
+)
+
+var errUnterminatedQuote = errors.New("unterminated quote")
+
+// splitArgs splits s into words the way a POSIX shell would, honouring
+// single quotes, double quotes and backslash escapes. open reports whether
+// the last word is still being typed, i.e. s does not end in a separator.
+func splitArgs(s string) (words []string, open bool, err error) {
+	var (
+		word    strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, c := range s {
+		switch {
+		case escaped:
+			word.WriteRune(c)
+			escaped = false
+		case c == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(c)
+		case c == '\'' || c == '"':
+			quote = c
+			inWord = true
+		case c == ' ' || c == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(c)
+			inWord = true
+		}
+	}
+
+	if inWord || quote != 0 || escaped {
+		words = append(words, word.String())
+		open = true
+	}
+	if quote != 0 || escaped {
+		err = errUnterminatedQuote
+	}
+
+	return words, open, err
+}
+
+// splitPipeline splits s into the stages of a pipeline at every '|' that
+// is neither quoted nor escaped, the stages being left as typed.
+func splitPipeline(s string) []string {
+	var (
+		stages  []string
+		start   int
+		quote   rune
+		escaped bool
+	)
+
+	for i, c := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\' && quote != '\'':
+			escaped = true
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '|':
+			stages = append(stages, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(stages, s[start:])
+}
+
+// quoteArg quotes s so that splitArgs reads it back as a single word.
+func quoteArg(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t'\"\\|") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// validateArgs checks args against the argument schema of a metacommand.
+// Metacommands without a schema accept any arguments.
+func validateArgs(schema []*daemonproto.MetacommandArgument, args []string) error {
+	if len(schema) == 0 {
+		return nil
+	}
+	if len(schema) < len(args) {
+		return fmt.Errorf("too many arguments: expected at most %d, got %d", len(schema), len(args))
+	}
+
+	for idx, arg := range schema {
+		if len(args) <= idx {
+			if arg.Required {
+				return fmt.Errorf("missing required argument %s", arg.Name)
+			}
+			continue
+		}
+
+		if err := validateValue(arg.Type, arg.EnumValues, args[idx]); err != nil {
+			return fmt.Errorf("invalid value %q for argument %s: %w", args[idx], arg.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// validateValue checks that value is of type t, enums taking enumValues.
+func validateValue(t daemonproto.MetacommandArgumentType, enumValues []string, value string) error {
+	var err error
+	switch t {
+	case daemonproto.MetacommandArgumentType_INT:
+		_, err = strconv.Atoi(value)
+	case daemonproto.MetacommandArgumentType_FLOAT:
+		_, err = strconv.ParseFloat(value, 64)
+	case daemonproto.MetacommandArgumentType_BOOL:
+		_, err = strconv.ParseBool(value)
+	case daemonproto.MetacommandArgumentType_ENUM:
+		if !slices.Contains(enumValues, value) {
+			err = fmt.Errorf("must be one of %s", strings.Join(enumValues, ", "))
+		}
+	}
+	return unwrapNumError(err)
+}
+
+// validatePipedArgs checks the arguments of a pipeline stage, the input
+// piped from the previous stage being its argument after args.
+func validatePipedArgs(schema []*daemonproto.MetacommandArgument, args []string) error {
+	if len(schema) == 0 {
+		return nil
+	}
+	if len(schema) <= len(args) {
+		return fmt.Errorf("too many arguments: expected at most %d besides the piped input, got %d", len(schema)-1, len(args))
+	}
+	for _, arg := range schema[len(args)+1:] {
+		if arg.Required {
+			return fmt.Errorf("missing required argument %s", arg.Name)
+		}
+	}
+
+	return validateArgs(schema[:len(args)], args)
+}
+
+func unwrapNumError(err error) error {
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		return numErr.Err
+	}
+	return err
+}
+
+// usage describes the arguments of a metacommand, e.g. "logging::history <filter> [limit:int]".
+func usage(name string, schema []*daemonproto.MetacommandArgument) string {
+	parts := []string{name}
+	for _, arg := range schema {
+		a := arg.Name
+		switch arg.Type {
+		case daemonproto.MetacommandArgumentType_ENUM:
+			a += ":" + strings.Join(arg.EnumValues, "|")
+		case daemonproto.MetacommandArgumentType_STRING:
+		default:
+			a += ":" + strings.ToLower(arg.Type.String())
+		}
+		if arg.Required {
+			a = "<" + a + ">"
+		} else {
+			a = "[" + a + "]"
+		}
+		parts = append(parts, a)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,22 @@
+package metamode
+
+import (
+	"encoding/base64"
+	"io"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raphaelreyna/metashell/internal/log"
+)
+
+// copyText copies s to the clipboard through the terminal using an OSC 52
+// sequence, which also works over ssh.
+func copyText(s string) tea.Cmd {
+	return func() tea.Msg {
+		seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(s)) + "\a"
+		if _, err := io.WriteString(os.Stdout, seq); err != nil {
+			log.Error("error copying to the clipboard", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,168 @@
+package metamode
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// maxCompletions is the number of completions shown under the input.
+const maxCompletions = 5
+
+// completion is a metacommand matching the word being typed.
+type completion struct {
+	value       string
+	description string
+	// matched holds the indexes of the characters of value matching the word.
+	matched []int
+}
+
+// refreshCompletions fuzzy matches the metacommand being typed against the
+// metacommands of every plugin, best matches first. There are no completions
+// once the metacommand has been typed and its arguments are being typed.
+func (ms *mainScreen) refreshCompletions() {
+	value := ms.input.Value()
+	if value == ms.completedFor && ms.completions != nil {
+		return
+	}
+	ms.completedFor = value
+	ms.completions = ms.completions[:0]
+	ms.selected = 0
+
+	_, stage := ms.stageInput()
+	words, open, _ := splitArgs(stage)
+	if ms.searching || 1 < len(words) || (len(words) == 1 && !open) {
+		return
+	}
+
+	var candidates []string
+	for pluginName, mcNames := range ms.completionData {
+		for _, mcn := range mcNames {
+			candidates = append(candidates, pluginName+ms.pluginNameDelim+mcn)
+		}
+	}
+	sort.Strings(candidates)
+
+	if len(words) == 0 {
+		for _, c := range candidates {
+			ms.completions = append(ms.completions, ms.completion(c, nil))
+		}
+		return
+	}
+
+	for _, match := range fuzzy.Find(words[0], candidates) {
+		ms.completions = append(ms.completions, ms.completion(match.Str, match.MatchedIndexes))
+	}
+}
+
+func (ms *mainScreen) completion(value string, matched []int) completion {
+	pn, mn, _ := strings.Cut(value, ms.pluginNameDelim)
+	return completion{
+		value:       value,
+		description: ms.metacommands[pn][mn].GetDescription(),
+		matched:     matched,
+	}
+}
+
+// moveSelection moves the selected completion by delta, wrapping around.
+func (ms *mainScreen) moveSelection(delta int) {
+	if n := len(ms.completions); 0 < n {
+		ms.selected = ((ms.selected+delta)%n + n) % n
+	}
+}
+
+// acceptCompletion replaces the metacommand being typed with the selected
+// completion, reporting whether there was one.
+func (ms *mainScreen) acceptCompletion() bool {
+	if len(ms.completions) == 0 {
+		return false
+	}
+	prefix, _ := ms.stageInput()
+	if strings.HasSuffix(prefix, "|") {
+		prefix += " "
+	}
+	ms.input.SetValue(prefix + ms.completions[ms.selected].value + " ")
+	ms.input.CursorEnd()
+	return true
+}
+
+// ghost returns the rest of the selected completion if it extends what
+// has been typed so far.
+func (ms *mainScreen) ghost() string {
+	if len(ms.completions) == 0 {
+		return ""
+	}
+	_, value := ms.stageInput()
+	if c := ms.completions[ms.selected].value; strings.HasPrefix(c, value) {
+		return c[len(value):]
+	}
+	return ""
+}
+
+// inputView renders the input with the ghost text of the selected
+// completion after the cursor.
+func (ms *mainScreen) inputView() string {
+	var (
+		ghost = ms.ghost()
+		value = ms.input.Value()
+	)
+	if ghost == "" || ms.input.Cursor() != len([]rune(value)) {
+		return ms.input.View()
+	}
+
+	_, size := utf8.DecodeRuneInString(ghost)
+	first, rest := ghost[:size], ghost[size:]
+	cursor := ms.input.CursorStyle.Copy().Inline(true).Reverse(true)
+	if ms.input.Blink() {
+		cursor = ms.theme.ghost
+	}
+
+	return ms.input.PromptStyle.Render(ms.input.Prompt) +
+		ms.input.TextStyle.Copy().Inline(true).Render(value) +
+		cursor.Render(first) +
+		ms.theme.ghost.Render(rest)
+}
+
+// completionsView renders the completions in a window around the selected one.
+func (ms *mainScreen) completionsView() string {
+	if len(ms.completions) == 0 {
+		return ""
+	}
+
+	start := max(0, ms.selected-maxCompletions+1)
+	end := min(len(ms.completions), start+maxCompletions)
+
+	lines := make([]string, 0, maxCompletions)
+	for idx := start; idx < end; idx++ {
+		var (
+			c      = ms.completions[idx]
+			style  = ms.theme.text
+			marker = "  "
+			sb     strings.Builder
+		)
+		if idx == ms.selected {
+			style, marker = ms.theme.selected, "> "
+		}
+		matchStyle := style.Copy().Underline(true)
+
+		sb.WriteString(style.Render(marker))
+		for i, r := range c.value {
+			if slices.Contains(c.matched, i) {
+				sb.WriteString(matchStyle.Render(string(r)))
+			} else {
+				sb.WriteString(style.Render(string(r)))
+			}
+		}
+		if c.description != "" {
+			sb.WriteString("  " + ms.theme.description.Render(c.description))
+		}
+
+		lines = append(lines, sb.String())
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
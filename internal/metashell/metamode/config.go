@@ -0,0 +1,87 @@
+package metamode
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// minPopupHeight is the fewest lines an inline popup is given,
+// terminal permitting, since the main screen alone needs five.
+const minPopupHeight = 10
+
+type Config struct {
+	// HistoryFile is where executed metacommands are kept across sessions.
+	HistoryFile string `yaml:"history_file"`
+	// HistorySize is the number of metacommands kept in the history.
+	HistorySize int         `yaml:"history_size"`
+	Theme       ThemeConfig `yaml:"theme"`
+	Keys        KeysConfig  `yaml:"keys"`
+	// Display is fullscreen, drawing metamode on the alternate screen,
+	// or inline, drawing it as a popup below the prompt.
+	Display string `yaml:"display"`
+	// Height is the height of the inline popup, either in lines or as
+	// a percentage of the terminal height such as "40%".
+	Height string `yaml:"height"`
+	// Timeout is how long metacommands are given to complete.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+func (c *Config) SetDefaults(rootDir string) {
+	if c.HistoryFile == "" {
+		c.HistoryFile = filepath.Join(rootDir, "metamode_history")
+	}
+	if c.HistorySize == 0 {
+		c.HistorySize = 1000
+	}
+	if c.Display == "" {
+		c.Display = "fullscreen"
+	}
+	if c.Height == "" {
+		c.Height = "40%"
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 30 * time.Second
+	}
+}
+
+func (c Config) Validate() error {
+	switch c.Display {
+	case "fullscreen", "inline":
+	default:
+		return fmt.Errorf("unknown metamode display %q (displays: fullscreen, inline)", c.Display)
+	}
+	if _, _, err := c.height(); err != nil {
+		return err
+	}
+	if _, err := newTheme(c.Theme); err != nil {
+		return err
+	}
+	_, err := newKeymap(c.Keys)
+	return err
+}
+
+func (c Config) Inline() bool {
+	return c.Display == "inline"
+}
+
+// PopupHeight returns the height of the inline popup in a terminal with
+// the given number of rows, leaving a row for the prompt.
+func (c Config) PopupHeight(rows int) int {
+	n, percent, _ := c.height()
+	if percent {
+		n = rows * n / 100
+	}
+	return max(min(max(n, minPopupHeight), rows-1), 1)
+}
+
+func (c Config) height() (n int, percent bool, err error) {
+	s, percent := strings.CutSuffix(c.Height, "%")
+	n, err = strconv.Atoi(s)
+	if err != nil || n <= 0 || (percent && 100 < n) {
+		return 0, false, fmt.Errorf("invalid metamode height %q: must be a number of lines or a percentage", c.Height)
+	}
+	return n, percent, nil
+}
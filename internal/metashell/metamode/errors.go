@@ -0,0 +1,64 @@
+package metamode
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/lipgloss"
+	daemonproto "github.com/raphaelreyna/metashell/internal/rpc/go/daemon"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errReplaceUnsupported is the error of SHELL_REPLACE responses when the
+// shell cannot have its command line cleared.
+var errReplaceUnsupported = errors.New("replacing the command line takes the shell hooks, which are only installed with the hooks delineation")
+
+// screenInitErrorMsg is sent to the active screen when the
+// screen it asked to move on to failed to initialize.
+type screenInitErrorMsg struct {
+	screen string
+	err    error
+}
+
+// metacommandError is a failed metacommand or pipeline, shown in a
+// dialog offering to run it again or to go back to editing it.
+type metacommandError struct {
+	run *run
+	err error
+}
+
+// responseError returns the error of a metacommand call, be it that of the
+// call or the one the plugin set in its response.
+func responseError(resp *daemonproto.MetacommandResponse, err error) error {
+	if err == nil && resp.GetError() != "" {
+		return errors.New(resp.GetError())
+	}
+	return err
+}
+
+// errorMessage describes err for the user, without the gRPC wrapping
+// that errors returned by the daemon or its plugins come with.
+func errorMessage(err error) string {
+	s, ok := status.FromError(err)
+	if !ok {
+		return err.Error()
+	}
+	switch s.Code() {
+	case codes.Unavailable:
+		return "could not reach the metashell daemon: " + s.Message()
+	case codes.DeadlineExceeded, codes.Canceled:
+		return "the metashell daemon did not respond in time"
+	}
+	return s.Message()
+}
+
+// errorDialog renders e with the bindings retrying and editing the metacommand.
+func (ms *mainScreen) errorDialog(e *metacommandError) string {
+	w, _ := ms.size()
+	return ms.theme.dialog.Copy().Width(min(max(w-4, 20), 72)).Render(lipgloss.JoinVertical(lipgloss.Left,
+		ms.theme.dialogTitle.Render(e.run.name+" failed"),
+		ms.theme.text.Render(errorMessage(e.err)),
+		"",
+		ms.theme.shortHelp(relabeled(ms.keys.Execute, "retry"), relabeled(ms.keys.Back, "edit")),
+	))
+}
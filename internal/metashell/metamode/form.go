@@ -0,0 +1,395 @@
+package metamode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	daemonproto "github.com/raphaelreyna/metashell/internal/rpc/go/daemon"
+)
+
+// form is the data of a FORM response.
+type form struct {
+	Title        string       `json:"title"`
+	Fields       []*formField `json:"fields"`
+	SubmitFormat string       `json:"submit_format"`
+}
+
+type formField struct {
+	Name        string   `json:"name"`
+	Label       string   `json:"label"`
+	Description string   `json:"description"`
+	Type        string   `json:"type"`
+	Options     []string `json:"options"`
+	Default     string   `json:"default"`
+	Required    bool     `json:"required"`
+	Pattern     string   `json:"pattern"`
+
+	argType daemonproto.MetacommandArgumentType
+	pattern *regexp.Regexp
+	// input holds the value of text fields, option the index of the value
+	// of enum fields and checked the value of bool fields.
+	input   textinput.Model
+	option  int
+	checked bool
+	// err is why the value was rejected on submission.
+	err string
+}
+
+type formScreenInitData struct {
+	title string
+	data  []byte
+}
+
+// formSubmitMsg carries the values of a submitted form to the screen that
+// showed it, for the metacommand that returned the form to be called with
+// them, format being the format of its response.
+type formSubmitMsg struct {
+	values map[string]string
+	format daemonproto.MetacommandResponseFormat
+}
+
+// formScreen lets the user fill in the form returned by a metacommand.
+type formScreen struct {
+	title  string
+	fields []*formField
+	format daemonproto.MetacommandResponseFormat
+	// focus is the index of the field being filled in.
+	focus int
+
+	next  func(string, any)
+	size  func() (int, int)
+	theme *theme
+	keys  *keymap
+}
+
+// parseForm reads and checks the data of a FORM response.
+func parseForm(data []byte) (*form, daemonproto.MetacommandResponseFormat, error) {
+	var f form
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, 0, err
+	}
+	if len(f.Fields) == 0 {
+		return nil, 0, errors.New("form without fields")
+	}
+
+	format := daemonproto.MetacommandResponseFormat_SHELL_INJECTION
+	if f.SubmitFormat != "" {
+		v, ok := daemonproto.MetacommandResponseFormat_value[strings.ToUpper(f.SubmitFormat)]
+		format = daemonproto.MetacommandResponseFormat(v)
+		if !ok || format == daemonproto.MetacommandResponseFormat_UNSPECIFIED || format == daemonproto.MetacommandResponseFormat_FORM {
+			return nil, 0, fmt.Errorf("invalid submit format %q", f.SubmitFormat)
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, field := range f.Fields {
+		if field.Name == "" {
+			return nil, 0, errors.New("form field without a name")
+		}
+		if names[field.Name] {
+			return nil, 0, fmt.Errorf("duplicate form field %s", field.Name)
+		}
+		names[field.Name] = true
+		if field.Label == "" {
+			field.Label = field.Name
+		}
+
+		if field.Type == "" {
+			field.Type = "string"
+		}
+		t, ok := daemonproto.MetacommandArgumentType_value[strings.ToUpper(field.Type)]
+		if !ok {
+			return nil, 0, fmt.Errorf("invalid type %q for form field %s", field.Type, field.Name)
+		}
+		field.argType = daemonproto.MetacommandArgumentType(t)
+
+		switch field.argType {
+		case daemonproto.MetacommandArgumentType_ENUM:
+			if len(field.Options) == 0 {
+				return nil, 0, fmt.Errorf("enum form field %s without options", field.Name)
+			}
+			if field.Default != "" {
+				field.option = slices.Index(field.Options, field.Default)
+				if field.option == -1 {
+					return nil, 0, fmt.Errorf("default %q of form field %s is not one of its options", field.Default, field.Name)
+				}
+			}
+		case daemonproto.MetacommandArgumentType_BOOL:
+			if field.Default != "" {
+				var err error
+				if field.checked, err = strconv.ParseBool(field.Default); err != nil {
+					return nil, 0, fmt.Errorf("invalid default %q for form field %s", field.Default, field.Name)
+				}
+			}
+		}
+
+		if field.Pattern != "" {
+			var err error
+			if field.pattern, err = regexp.Compile(field.Pattern); err != nil {
+				return nil, 0, fmt.Errorf("invalid pattern for form field %s: %w", field.Name, err)
+			}
+		}
+	}
+
+	return &f, format, nil
+}
+
+func (s *formScreen) Name() string {
+	return "form_screen"
+}
+
+func (s *formScreen) Init(rs rootScreen, data any) (tea.Cmd, error) {
+	s.next = rs.next
+	s.size = rs.size
+	s.theme = rs.theme()
+	s.keys = rs.keys()
+
+	initData, ok := data.(formScreenInitData)
+	if !ok {
+		return nil, nil
+	}
+	f, format, err := parseForm(initData.data)
+	if err != nil {
+		return nil, err
+	}
+
+	s.title = f.Title
+	if s.title == "" {
+		s.title = initData.title
+	}
+	s.fields = f.Fields
+	s.format = format
+
+	for _, field := range s.fields {
+		if field.text() {
+			field.input = textinput.New()
+			field.input.Prompt = ""
+			field.input.TextStyle = s.theme.text.Copy()
+			field.input.SetValue(field.Default)
+			field.input.CursorEnd()
+		}
+	}
+	s.resize()
+	s.focus = 0
+
+	return s.focusField(0), nil
+}
+
+func (s *formScreen) Update(msg tea.Msg) (screen, tea.Cmd) {
+	if len(s.fields) == 0 {
+		return s, nil
+	}
+	field := s.fields[s.focus]
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.resize()
+		return s, nil
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, s.keys.NextField):
+			return s, s.focusField((s.focus + 1) % len(s.fields))
+		case key.Matches(msg, s.keys.PrevField):
+			return s, s.focusField((s.focus - 1 + len(s.fields)) % len(s.fields))
+		case key.Matches(msg, s.keys.Execute):
+			return s, s.submit()
+		}
+
+		switch field.argType {
+		case daemonproto.MetacommandArgumentType_ENUM:
+			switch n := len(field.Options); {
+			case key.Matches(msg, s.keys.NextOption):
+				field.option = (field.option + 1) % n
+			case key.Matches(msg, s.keys.PrevOption):
+				field.option = (field.option - 1 + n) % n
+			}
+			return s, nil
+		case daemonproto.MetacommandArgumentType_BOOL:
+			if key.Matches(msg, s.keys.MultiSelect) {
+				field.checked = !field.checked
+			}
+			return s, nil
+		}
+		field.err = ""
+	}
+
+	if !field.text() {
+		return s, nil
+	}
+	var cmd tea.Cmd
+	field.input, cmd = field.input.Update(msg)
+	return s, cmd
+}
+
+func (s *formScreen) View() string {
+	var (
+		lw    int
+		lines = []string{s.theme.title.Render(s.title), ""}
+	)
+	for _, field := range s.fields {
+		lw = max(lw, lipgloss.Width(field.label()))
+	}
+	indent := strings.Repeat(" ", lw+4)
+
+	for idx, field := range s.fields {
+		var (
+			style  = s.theme.text
+			marker = "  "
+		)
+		if idx == s.focus {
+			style, marker = s.theme.selected, "> "
+		}
+		label := style.Render(marker + field.label() + strings.Repeat(" ", lw-lipgloss.Width(field.label())) + "  ")
+		lines = append(lines, label+s.fieldView(field))
+
+		if idx == s.focus && field.Description != "" {
+			lines = append(lines, indent+s.theme.description.Render(field.Description))
+		}
+		if field.err != "" {
+			lines = append(lines, indent+s.theme.error.Copy().PaddingLeft(0).Render(field.err))
+		}
+	}
+
+	lines = append(lines, "", s.theme.footer.Render(s.theme.shortHelp(
+		relabeled(s.keys.Execute, "submit"), s.keys.NextField, s.keys.Back, s.keys.Help,
+	)))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func (s *formScreen) Keys() [][]key.Binding {
+	return [][]key.Binding{
+		{relabeled(s.keys.Execute, "submit"), s.keys.NextField, s.keys.PrevField},
+		{s.keys.NextOption, s.keys.PrevOption, relabeled(s.keys.MultiSelect, "toggle")},
+	}
+}
+
+// capturesKey keeps the keys typing into text fields from the global bindings.
+func (s *formScreen) capturesKey(msg tea.KeyMsg) bool {
+	return 0 < len(s.fields) && s.fields[s.focus].text() && printable(msg)
+}
+
+// fieldView renders the value of field: the text being typed, the options
+// with the selected one highlighted, or a checkbox.
+func (s *formScreen) fieldView(field *formField) string {
+	switch field.argType {
+	case daemonproto.MetacommandArgumentType_ENUM:
+		options := make([]string, len(field.Options))
+		for idx, o := range field.Options {
+			if idx == field.option {
+				options[idx] = s.theme.selected.Render("[" + o + "]")
+			} else {
+				options[idx] = s.theme.description.Render(" " + o + " ")
+			}
+		}
+		return strings.Join(options, " ")
+	case daemonproto.MetacommandArgumentType_BOOL:
+		if field.checked {
+			return s.theme.text.Render("[x]")
+		}
+		return s.theme.text.Render("[ ]")
+	}
+	return field.input.View()
+}
+
+// focusField moves the focus to the field at idx.
+func (s *formScreen) focusField(idx int) tea.Cmd {
+	if f := s.fields[s.focus]; f.text() {
+		f.input.Blur()
+	}
+	s.focus = idx
+	if f := s.fields[idx]; f.text() {
+		return f.input.Focus()
+	}
+	return nil
+}
+
+// submit validates the fields, focusing the first invalid one, and hands
+// their values over to the screen that showed the form.
+func (s *formScreen) submit() tea.Cmd {
+	var (
+		values  = make(map[string]string, len(s.fields))
+		invalid = -1
+	)
+	for idx, field := range s.fields {
+		value := field.value()
+		field.err = ""
+		if err := field.validate(value); err != nil {
+			field.err = err.Error()
+			if invalid == -1 {
+				invalid = idx
+			}
+		}
+		values[field.Name] = value
+	}
+	if invalid != -1 {
+		return s.focusField(invalid)
+	}
+
+	submitted := formSubmitMsg{values: values, format: s.format}
+	s.next("back", nil)
+	return func() tea.Msg { return submitted }
+}
+
+func (s *formScreen) resize() {
+	w, _ := s.size()
+	var lw int
+	for _, field := range s.fields {
+		lw = max(lw, lipgloss.Width(field.label()))
+	}
+	for _, field := range s.fields {
+		field.input.Width = max(w-lw-6, 10)
+	}
+}
+
+func (f *formField) label() string {
+	if f.Required {
+		return f.Label + "*"
+	}
+	return f.Label
+}
+
+// text reports whether the value of f is typed in.
+func (f *formField) text() bool {
+	switch f.argType {
+	case daemonproto.MetacommandArgumentType_ENUM, daemonproto.MetacommandArgumentType_BOOL:
+		return false
+	}
+	return true
+}
+
+func (f *formField) value() string {
+	switch f.argType {
+	case daemonproto.MetacommandArgumentType_ENUM:
+		return f.Options[f.option]
+	case daemonproto.MetacommandArgumentType_BOOL:
+		return strconv.FormatBool(f.checked)
+	}
+	return f.input.Value()
+}
+
+// validate checks value against the type, options and pattern of f.
+func (f *formField) validate(value string) error {
+	if value == "" {
+		if f.Required {
+			return errors.New("required")
+		}
+		return nil
+	}
+	if err := validateValue(f.argType, f.Options, value); err != nil {
+		return fmt.Errorf("invalid value %q: %w", value, err)
+	}
+	if f.pattern != nil && !f.pattern.MatchString(value) {
+		return fmt.Errorf("invalid value %q: must match %s", value, f.Pattern)
+	}
+	return nil
+}
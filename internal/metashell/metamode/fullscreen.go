@@ -0,0 +1,171 @@
+package metamode
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/raphaelreyna/metashell/internal/log"
+	daemonproto "github.com/raphaelreyna/metashell/internal/rpc/go/daemon"
+	"google.golang.org/protobuf/proto"
+)
+
+// fullscreenFooterHeight is the number of lines the fullscreen screen
+// keeps for itself; plugins are asked for content that fits the rest.
+const fullscreenFooterHeight = 1
+
+// screenFormatArgs returns the format args telling a plugin what size its
+// SCREEN content should be.
+func screenFormatArgs(w, h int) []string {
+	return []string{fmt.Sprintf("size=%dx%d", w, h-fullscreenFooterHeight)}
+}
+
+// fullscreenInitData holds the content returned for req, which is
+// sent again, form values and all, when the terminal is resized.
+type fullscreenInitData struct {
+	req     *daemonproto.MetacommandRequest
+	content string
+	w, h    int
+}
+
+// fullscreenContentMsg carries content re-requested for a new terminal size.
+type fullscreenContentMsg struct {
+	content string
+	w, h    int
+	err     error
+}
+
+// fullscreen shows the pre-sized, possibly ANSI styled, content of a SCREEN
+// metacommand, asking the plugin for new content whenever the terminal is resized.
+type fullscreen struct {
+	data fullscreenInitData
+	// wantW and wantH are the size of the latest content requested.
+	wantW, wantH int
+	// err is why the latest content could not be had, if it could not.
+	err string
+
+	next   func(string, any)
+	size   func() (int, int)
+	daemon daemonproto.MetashellDaemonClient
+	ctx    context.Context
+	cfg    Config
+	theme  *theme
+	keys   *keymap
+	vp     viewport.Model
+}
+
+func (s *fullscreen) Name() string {
+	return "fullscreen"
+}
+
+func (s *fullscreen) Init(rs rootScreen, data any) (tea.Cmd, error) {
+	s.data, _ = data.(fullscreenInitData)
+	s.wantW, s.wantH = s.data.w, s.data.h
+	s.err = ""
+
+	s.next = rs.next
+	s.size = rs.size
+	s.daemon = rs.daemon()
+	s.ctx = rs.ctx()
+	s.cfg = rs.config()
+	s.theme = rs.theme()
+	s.keys = rs.keys()
+
+	s.vp = viewport.New(0, 0)
+	s.resize()
+	s.vp.SetContent(s.data.content)
+
+	return nil, nil
+}
+
+func (s *fullscreen) Update(msg tea.Msg) (screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.resize()
+		if msg.Width != s.wantW || msg.Height != s.wantH {
+			s.wantW, s.wantH = msg.Width, msg.Height
+			return s, s.request(msg.Width, msg.Height)
+		}
+		return s, nil
+	case fullscreenContentMsg:
+		if msg.w != s.wantW || msg.h != s.wantH {
+			// a response to an outdated request
+			return s, nil
+		}
+		if msg.err != nil {
+			s.err = fmt.Sprintf("%s::%s failed: %s", s.data.req.GetPluginName(), s.data.req.GetMetaCommand(), errorMessage(msg.err))
+			return s, nil
+		}
+		s.err = ""
+		s.data.content = msg.content
+		s.data.w, s.data.h = msg.w, msg.h
+		s.vp.SetContent(msg.content)
+		return s, nil
+	}
+
+	var cmd tea.Cmd
+	s.vp, cmd = s.vp.Update(msg)
+	return s, cmd
+}
+
+func (s *fullscreen) View() string {
+	var (
+		lines = strings.Count(s.data.content, "\n") + 1
+		pages = (lines + s.vp.Height - 1) / max(s.vp.Height, 1)
+		page  = s.vp.YOffset/max(s.vp.Height, 1) + 1
+	)
+	if s.vp.AtBottom() {
+		page = pages
+	}
+
+	footer := s.theme.footer.Render(fmt.Sprintf("page %d/%d  ", page, max(pages, 1)) +
+		s.theme.shortHelp(s.vp.KeyMap.PageUp, s.vp.KeyMap.PageDown, s.keys.Back, s.keys.Help))
+	if s.err != "" {
+		footer = s.theme.error.Render(s.err)
+	}
+
+	// pad every line to the full width so that the content is not centered line by line
+	return lipgloss.NewStyle().Width(s.vp.Width).Render(s.vp.View()) + "\n" + footer
+}
+
+func (s *fullscreen) Keys() [][]key.Binding {
+	km := s.vp.KeyMap
+	return [][]key.Binding{
+		{km.Up, km.Down, km.PageUp, km.PageDown},
+		{km.HalfPageUp, km.HalfPageDown},
+	}
+}
+
+func (s *fullscreen) resize() {
+	w, h := s.size()
+	s.vp.Width = w
+	s.vp.Height = max(h-fullscreenFooterHeight, 0)
+}
+
+// request asks the plugin for content fitting a w by h terminal.
+func (s *fullscreen) request(w, h int) tea.Cmd {
+	req := proto.Clone(s.data.req).(*daemonproto.MetacommandRequest)
+	req.FormatArgs = screenFormatArgs(w, h)
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(s.ctx, s.cfg.Timeout)
+		defer cancel()
+		resp, err := s.daemon.Metacommand(ctx, req)
+		if err := responseError(resp, err); err != nil {
+			log.Error("error refreshing fullscreen content", err,
+				"plugin", req.PluginName,
+				"metacommand", req.MetaCommand,
+			)
+			return fullscreenContentMsg{w: w, h: h, err: err}
+		}
+		return fullscreenContentMsg{
+			content: string(resp.Data),
+			w:       w,
+			h:       h,
+		}
+	}
+}
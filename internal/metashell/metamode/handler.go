@@ -1,8 +1,12 @@
 package metamode
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"sync"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/raphaelreyna/metashell/internal/log"
@@ -13,6 +17,13 @@ type rootScreen interface {
 	next(string, any)
 	size() (w, h int)
 	daemon() daemonproto.MetashellDaemonClient
+	config() Config
+	theme() *theme
+	keys() *keymap
+	// ctx is canceled when metamode quits.
+	ctx() context.Context
+	// commandLine is the command line being typed when metamode was entered.
+	commandLine() CommandLine
 }
 
 type screen interface {
@@ -20,28 +31,75 @@ type screen interface {
 	Init(rootScreen, any) (tea.Cmd, error)
 	Update(tea.Msg) (screen, tea.Cmd)
 	View() string
+	// Keys returns the bindings of the screen, grouped for the help view.
+	Keys() [][]key.Binding
+}
+
+// keyCapturer is implemented by screens that need some keys for themselves,
+// e.g. to type text, before they are matched against the global bindings.
+type keyCapturer interface {
+	capturesKey(tea.KeyMsg) bool
+}
+
+// CommandLine is the command line being typed when metamode is entered.
+type CommandLine struct {
+	Text string
+	// Replaceable is whether the shell can have the line replaced, which
+	// takes the key the shell hooks bind to clearing it.
+	Replaceable bool
 }
 
 type Handler struct {
+	cfg            Config
+	th             *theme
+	km             *keymap
 	w, h           int
 	daemonClient   daemonproto.MetashellDaemonClient
+	context        context.Context
+	cancel         context.CancelFunc
 	metaCommandOut string
-	screens        map[string]screen
+	// replaceLine is whether metaCommandOut replaces
+	// cmdLine rather than being appended to it.
+	replaceLine bool
+	cmdLine     CommandLine
+	screens     map[string]screen
+
+	activeScreen screen
+	showHelp     bool
+	quitting     bool
+	// history holds the screens to go back to, most recent last.
+	history []screen
 
-	activeScreen            screen
 	newActiveScreen         string
 	newActiveScreenInitData any
 	sync.Mutex
 }
 
-func (m *Handler) Initialize(daemon daemonproto.MetashellDaemonClient, quit func()) error {
+func (m *Handler) Initialize(config Config, daemon daemonproto.MetashellDaemonClient, commandLine CommandLine, quit func()) error {
+	th, err := newTheme(config.Theme)
+	if err != nil {
+		return err
+	}
+	km, err := newKeymap(config.Keys)
+	if err != nil {
+		return err
+	}
+
+	m.cfg = config
+	m.th = th
+	m.km = km
+	m.context, m.cancel = context.WithCancel(context.Background())
 	m.daemonClient = daemon
+	m.cmdLine = commandLine
 	m.screens = map[string]screen{
 		"main_screen": &mainScreen{
-			prompt:          "> ",
+			prompt:          th.prompt,
 			pluginNameDelim: "::",
 		},
 		"list_screen": &listScreen{},
+		"text_screen": &textScreen{},
+		"fullscreen":  &fullscreen{},
+		"form_screen": &formScreen{},
 	}
 	m.activeScreen = m.screens["main_screen"]
 	return nil
@@ -51,6 +109,12 @@ func (m *Handler) GetShellInjection() string {
 	return m.metaCommandOut
 }
 
+// ReplacesCommandLine reports whether the shell injection replaces the
+// command line being typed rather than being appended to it.
+func (m *Handler) ReplacesCommandLine() bool {
+	return m.replaceLine
+}
+
 func (m *Handler) Init() tea.Cmd {
 	for _, scrn := range m.screens {
 		if scrn != m.activeScreen {
@@ -60,7 +124,11 @@ func (m *Handler) Init() tea.Cmd {
 
 	cmd, err := m.activeScreen.Init(m, nil)
 	if err != nil {
-		panic(err)
+		log.Error("error initializing screen", err,
+			"screen-name", m.activeScreen.Name(),
+		)
+		initErr := screenInitErrorMsg{screen: m.activeScreen.Name(), err: err}
+		return tea.Batch(cmd, func() tea.Msg { return initErr })
 	}
 
 	return cmd
@@ -70,14 +138,39 @@ func (m *Handler) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	m.Lock()
 	defer m.Unlock()
 	if m.activeScreen == nil {
-		return m, tea.Quit
+		return m, m.quit()
+	}
+
+	if ws, ok := msg.(tea.WindowSizeMsg); ok && m.cfg.Inline() {
+		// screens only get the popup to draw on
+		ws.Height = m.cfg.PopupHeight(ws.Height)
+		msg = ws
 	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch key := msg.String(); key {
-		case "esc":
-			return m, tea.Quit
+		if key.Matches(msg, m.km.Quit) {
+			return m, m.quit()
+		}
+		if m.showHelp {
+			if key.Matches(msg, m.km.Help, m.km.Back) {
+				m.showHelp = false
+			}
+			return m, nil
+		}
+		if c, ok := m.activeScreen.(keyCapturer); ok && c.capturesKey(msg) {
+			break
+		}
+		switch {
+		case key.Matches(msg, m.km.Help):
+			m.showHelp = true
+			return m, nil
+		case key.Matches(msg, m.km.Back):
+			if len(m.history) == 0 {
+				return m, m.quit()
+			}
+			m.back()
+			return m, nil
 		}
 	case tea.WindowSizeMsg:
 		m.w = msg.Width
@@ -88,33 +181,104 @@ func (m *Handler) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch m.newActiveScreen {
 	case "":
 		m.activeScreen = s
-	case "shell_injection":
+	case "shell_injection", "shell_replace":
 		m.metaCommandOut = m.newActiveScreenInitData.(string)
-		c = tea.Quit
+		m.replaceLine = m.newActiveScreen == "shell_replace"
+		c = m.quit()
+	case "back":
+		m.back()
+	case "pipeline":
+		// the value picked from a list is piped into the rest of
+		// the pipeline, which is run from the screen before it
+		m.back()
+		input := pipelineInputMsg{value: fmt.Sprint(m.newActiveScreenInitData)}
+		c = tea.Batch(c, func() tea.Msg { return input })
 	default:
 		if s := m.screens[m.newActiveScreen]; s != nil {
-			s.Init(m, m.newActiveScreenInitData)
-			m.activeScreen = s
-			m.newActiveScreen = ""
-			m.newActiveScreenInitData = nil
+			initCmd, err := s.Init(m, m.newActiveScreenInitData)
+			if err != nil {
+				log.Error("error initializing screen", err,
+					"screen-name", m.newActiveScreen,
+				)
+				initErr := screenInitErrorMsg{screen: m.newActiveScreen, err: err}
+				c = tea.Batch(c, func() tea.Msg { return initErr })
+				break
+			}
+			m.enter(s)
+			c = tea.Batch(c, initCmd)
 		} else {
 			log.Warn("could not find screen by name",
 				"screen-name", m.newActiveScreen,
 			)
 		}
 	}
+	if m.newActiveScreen != "shell_injection" && m.newActiveScreen != "shell_replace" {
+		m.newActiveScreen = ""
+		m.newActiveScreenInitData = nil
+	}
 
 	return m, c
 }
 
+// enter makes s the active screen, recording the current one to go back to.
+// Entering a screen that is already in the history goes back to it instead.
+func (m *Handler) enter(s screen) {
+	for i, hs := range m.history {
+		if hs == s {
+			m.history = m.history[:i]
+			m.activeScreen = s
+			return
+		}
+	}
+	if m.activeScreen != s {
+		m.history = append(m.history, m.activeScreen)
+	}
+	m.activeScreen = s
+}
+
+// quit quits metamode, clearing the view first so that
+// nothing is left behind when drawing inline.
+func (m *Handler) quit() tea.Cmd {
+	m.quitting = true
+	m.cancel()
+	return tea.Quit
+}
+
+func (m *Handler) back() {
+	if len(m.history) == 0 {
+		return
+	}
+	m.activeScreen = m.history[len(m.history)-1]
+	m.history = m.history[:len(m.history)-1]
+}
+
 func (m *Handler) View() string {
-	if m.activeScreen == nil {
+	if m.activeScreen == nil || m.quitting {
+		return ""
+	}
+
+	view := m.activeScreen.View()
+	if m.showHelp {
+		groups := append(m.activeScreen.Keys(), m.km.global())
+		view = m.th.fullHelp(groups, m.w)
+	}
+
+	if !m.cfg.Inline() {
+		return lipgloss.Place(m.w, m.h,
+			lipgloss.Center, lipgloss.Center,
+			view,
+		)
+	}
+
+	// drawing past the popup would scroll the terminal
+	if m.h == 0 {
 		return ""
 	}
-	return lipgloss.Place(m.w, m.h,
-		lipgloss.Center, lipgloss.Center,
-		m.activeScreen.View(),
-	)
+	lines := strings.Split(view, "\n")
+	if m.h < len(lines) {
+		lines = lines[:m.h]
+	}
+	return lipgloss.PlaceVertical(m.h, lipgloss.Top, strings.Join(lines, "\n"))
 }
 
 func (m *Handler) size() (w, h int) {
@@ -129,3 +293,23 @@ func (m *Handler) next(sn string, data any) {
 func (m *Handler) daemon() daemonproto.MetashellDaemonClient {
 	return m.daemonClient
 }
+
+func (m *Handler) config() Config {
+	return m.cfg
+}
+
+func (m *Handler) theme() *theme {
+	return m.th
+}
+
+func (m *Handler) keys() *keymap {
+	return m.km
+}
+
+func (m *Handler) ctx() context.Context {
+	return m.context
+}
+
+func (m *Handler) commandLine() CommandLine {
+	return m.cmdLine
+}
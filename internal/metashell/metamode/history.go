@@ -0,0 +1,79 @@
+package metamode
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+)
+
+// history holds the metacommands executed in metamode, oldest first,
+// persisted one per line.
+type history struct {
+	path    string
+	size    int
+	entries []string
+}
+
+func loadHistory(path string, size int) (*history, error) {
+	h := history{path: path, size: size}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &h, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(h.entries) > size {
+		h.entries = h.entries[len(h.entries)-size:]
+	}
+
+	return &h, nil
+}
+
+// add records line, skipping it if it repeats the latest entry.
+func (h *history) add(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" || (0 < len(h.entries) && h.entries[len(h.entries)-1] == line) {
+		return nil
+	}
+
+	h.entries = append(h.entries, line)
+	if len(h.entries) <= h.size {
+		file, err := os.OpenFile(h.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = file.WriteString(line + "\n")
+		return err
+	}
+
+	h.entries = h.entries[len(h.entries)-h.size:]
+	return os.WriteFile(h.path, []byte(strings.Join(h.entries, "\n")+"\n"), 0600)
+}
+
+// search returns the index of the latest entry before from containing
+// query, or -1 if there is none.
+func (h *history) search(query string, from int) int {
+	for idx := min(from, len(h.entries)) - 1; 0 <= idx; idx-- {
+		if strings.Contains(h.entries[idx], query) {
+			return idx
+		}
+	}
+	return -1
+}
@@ -0,0 +1,209 @@
+package metamode
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type KeysConfig struct {
+	// Preset is the built-in keymap to start from: default, vi or emacs.
+	Preset string `yaml:"preset"`
+	// Bindings maps actions to the keys bound to them, replacing
+	// the keys of the preset. An empty list unbinds the action.
+	Bindings map[string][]string `yaml:"bindings"`
+}
+
+// keymap holds the bindings of every metamode action.
+type keymap struct {
+	Quit key.Binding
+	Back key.Binding
+	Help key.Binding
+
+	Complete       key.Binding
+	Execute        key.Binding
+	NextCompletion key.Binding
+	PrevCompletion key.Binding
+	HistoryPrev    key.Binding
+	HistoryNext    key.Binding
+	Search         key.Binding
+
+	Select      key.Binding
+	MultiSelect key.Binding
+	Copy        key.Binding
+
+	NextField  key.Binding
+	PrevField  key.Binding
+	NextOption key.Binding
+	PrevOption key.Binding
+}
+
+var actionHelp = map[string]string{
+	"quit":            "quit",
+	"back":            "back",
+	"help":            "toggle help",
+	"complete":        "complete",
+	"execute":         "run",
+	"next_completion": "next completion",
+	"prev_completion": "previous completion",
+	"history_prev":    "previous in history",
+	"history_next":    "next in history",
+	"search":          "search history",
+	"select":          "select",
+	"multi_select":    "toggle selection",
+	"copy":            "copy",
+	"next_field":      "next field",
+	"prev_field":      "previous field",
+	"next_option":     "next option",
+	"prev_option":     "previous option",
+}
+
+var keyPresets = map[string]map[string][]string{
+	"default": {
+		"quit":            {"ctrl+c"},
+		"back":            {"esc", "q"},
+		"help":            {"f1"},
+		"complete":        {"tab"},
+		"execute":         {"enter"},
+		"next_completion": {"ctrl+n"},
+		"prev_completion": {"ctrl+p"},
+		"history_prev":    {"up"},
+		"history_next":    {"down"},
+		"search":          {"ctrl+r"},
+		"select":          {"enter"},
+		"multi_select":    {"space"},
+		"copy":            {"ctrl+y"},
+		"next_field":      {"tab", "down"},
+		"prev_field":      {"shift+tab", "up"},
+		"next_option":     {"right"},
+		"prev_option":     {"left"},
+	},
+	"vi": {
+		"quit":            {"ctrl+c"},
+		"back":            {"esc", "q"},
+		"help":            {"f1", "?"},
+		"complete":        {"tab"},
+		"execute":         {"enter"},
+		"next_completion": {"ctrl+j", "ctrl+n"},
+		"prev_completion": {"ctrl+k", "ctrl+p"},
+		"history_prev":    {"up"},
+		"history_next":    {"down"},
+		"search":          {"ctrl+r"},
+		"select":          {"enter"},
+		"multi_select":    {"space", "v"},
+		"copy":            {"ctrl+y", "y"},
+		"next_field":      {"tab", "down", "ctrl+j"},
+		"prev_field":      {"shift+tab", "up", "ctrl+k"},
+		"next_option":     {"right"},
+		"prev_option":     {"left"},
+	},
+	"emacs": {
+		"quit":            {"ctrl+c"},
+		"back":            {"esc", "ctrl+g"},
+		"help":            {"f1"},
+		"complete":        {"tab"},
+		"execute":         {"enter"},
+		"next_completion": {"ctrl+n"},
+		"prev_completion": {"ctrl+p"},
+		"history_prev":    {"up", "alt+p"},
+		"history_next":    {"down", "alt+n"},
+		"search":          {"ctrl+r"},
+		"select":          {"enter"},
+		"multi_select":    {"ctrl+@", "space"},
+		"copy":            {"alt+w"},
+		"next_field":      {"tab", "down", "ctrl+n"},
+		"prev_field":      {"shift+tab", "up", "ctrl+p"},
+		"next_option":     {"right", "ctrl+f"},
+		"prev_option":     {"left", "ctrl+b"},
+	},
+}
+
+func newKeymap(c KeysConfig) (*keymap, error) {
+	preset := c.Preset
+	if preset == "" {
+		preset = "default"
+	}
+	keys, ok := keyPresets[preset]
+	if !ok {
+		return nil, fmt.Errorf("unknown key preset %q (presets: %s)", preset, strings.Join(sortedKeys(keyPresets), ", "))
+	}
+
+	for action := range c.Bindings {
+		if _, ok := actionHelp[action]; !ok {
+			return nil, fmt.Errorf("unknown metamode action %q (actions: %s)", action, strings.Join(sortedKeys(actionHelp), ", "))
+		}
+	}
+
+	binding := func(action string) key.Binding {
+		ks, ok := c.Bindings[action]
+		if !ok {
+			ks = keys[action]
+		}
+		if len(ks) == 0 {
+			return key.NewBinding(key.WithDisabled())
+		}
+
+		// tea reports the space bar as " "
+		msgKeys := make([]string, len(ks))
+		for i, k := range ks {
+			if k == "space" {
+				k = " "
+			}
+			msgKeys[i] = k
+		}
+
+		return key.NewBinding(
+			key.WithKeys(msgKeys...),
+			key.WithHelp(strings.Join(ks, "/"), actionHelp[action]),
+		)
+	}
+
+	return &keymap{
+		Quit:           binding("quit"),
+		Back:           binding("back"),
+		Help:           binding("help"),
+		Complete:       binding("complete"),
+		Execute:        binding("execute"),
+		NextCompletion: binding("next_completion"),
+		PrevCompletion: binding("prev_completion"),
+		HistoryPrev:    binding("history_prev"),
+		HistoryNext:    binding("history_next"),
+		Search:         binding("search"),
+		Select:         binding("select"),
+		MultiSelect:    binding("multi_select"),
+		Copy:           binding("copy"),
+		NextField:      binding("next_field"),
+		PrevField:      binding("prev_field"),
+		NextOption:     binding("next_option"),
+		PrevOption:     binding("prev_option"),
+	}, nil
+}
+
+// global returns the bindings handled by the Handler, whatever the screen.
+func (k *keymap) global() []key.Binding {
+	return []key.Binding{k.Back, k.Quit, k.Help}
+}
+
+// relabeled returns b described as desc, for screens giving its action another meaning.
+func relabeled(b key.Binding, desc string) key.Binding {
+	b.SetHelp(b.Help().Key, desc)
+	return b
+}
+
+// printable reports whether msg types text, in which case it is left
+// to screens taking text input rather than matched against bindings.
+func printable(msg tea.KeyMsg) bool {
+	return (msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace) && !msg.Alt
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -2,10 +2,16 @@ package metamode
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -13,13 +19,6 @@ import (
 	daemonproto "github.com/raphaelreyna/metashell/internal/rpc/go/daemon"
 )
 
-var (
-	inputBorder = lipgloss.NewStyle().
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("63")).
-		Padding(1, 2)
-)
-
 type mainScreen struct {
 	prompt          string
 	pluginNameDelim string
@@ -29,9 +28,66 @@ type mainScreen struct {
 	next   func(string, any)
 	size   func() (int, int)
 	daemon daemonproto.MetashellDaemonClient
+	theme  *theme
+	keys   *keymap
+	// ctx is canceled when metamode quits, and every
+	// daemon call is given timeout to complete.
+	ctx     context.Context
+	timeout time.Duration
+	// commandLine is sent along with every metacommand.
+	commandLine CommandLine
 
 	completionData map[string][]string
-	formats        map[string]map[string]daemonproto.MetacommandResponseFormat
+	metacommands   map[string]map[string]*daemonproto.MetacommandInfo
+
+	// err is shown under the input until the next key press.
+	err string
+
+	history *history
+	// histIdx is the history entry being shown, len(history.entries)
+	// standing for the line being typed, which is kept in draft.
+	histIdx int
+	draft   string
+	// searching is whether a reverse search of the history for
+	// query is under way, match being the entry found.
+	searching bool
+	query     string
+	match     int
+
+	// completions are the metacommands matching what has been typed,
+	// completedFor, the one at selected being offered as ghost text.
+	completions  []completion
+	completedFor string
+	selected     int
+
+	// running is the metacommand or pipeline being run, if any, cancel canceling
+	// it and runID telling its result apart from those of canceled runs.
+	running *run
+	cancel  context.CancelFunc
+	runID   int
+	spinner spinner.Model
+	// last is the latest metacommand to have completed, lastRun
+	// the run it was part of, and failed the run whose error is
+	// being shown.
+	last    *daemonproto.MetacommandRequest
+	lastRun *run
+	failed  *metacommandError
+	// pending are the stages of a pipeline left to run once
+	// an item is picked from the list returned by the last one.
+	pending []*daemonproto.MetacommandRequest
+}
+
+// metacommandResultMsg carries the response to the run runID, req being the
+// request that was responded to and rest the pipeline stages left to run.
+// format, if set, overrides the format of the metacommand.
+type metacommandResultMsg struct {
+	runID  int
+	req    *daemonproto.MetacommandRequest
+	rest   []*daemonproto.MetacommandRequest
+	format daemonproto.MetacommandResponseFormat
+	w, h   int
+	resp   *daemonproto.MetacommandResponse
+	err    error
 }
 
 func (ms *mainScreen) Name() string {
@@ -44,41 +100,205 @@ func (ms *mainScreen) Init(r rootScreen, data any) (tea.Cmd, error) {
 	ms.next = r.next
 	ms.daemon = r.daemon()
 	ms.size = r.size
+	ms.theme = r.theme()
+	ms.keys = r.keys()
+	ms.ctx = r.ctx()
+	ms.timeout = r.config().Timeout
+	ms.commandLine = r.commandLine()
 
 	ms.input = textinput.New()
 	ms.input.Prompt = ms.prompt
+	ms.input.TextStyle = ms.theme.text.Copy()
+	ms.input.PromptStyle = ms.theme.text.Copy()
 	ms.input.SetValue(initData)
 	ms.input.Focus()
 
+	ms.input.CursorEnd()
+	ms.err = ""
+	ms.searching = false
+	ms.completions = nil
+	ms.failed = nil
+	ms.pending = nil
+	ms.stop()
+	ms.spinner = spinner.New(spinner.WithSpinner(spinner.Dot), spinner.WithStyle(ms.theme.selected))
+
+	cfg := r.config()
+	h, err := loadHistory(cfg.HistoryFile, cfg.HistorySize)
+	if err != nil {
+		log.Error("error loading metamode history", err)
+		h = &history{path: cfg.HistoryFile, size: cfg.HistorySize}
+	}
+	ms.history = h
+	ms.histIdx = len(h.entries)
+
 	ms.completionData = make(map[string][]string)
-	ms.formats = make(map[string]map[string]daemonproto.MetacommandResponseFormat)
+	ms.metacommands = make(map[string]map[string]*daemonproto.MetacommandInfo)
 
-	if err := ms.updatePlugins(context.TODO()); err != nil {
-		return nil, err
+	ctx, cancel := context.WithTimeout(ms.ctx, ms.timeout)
+	defer cancel()
+	if err := ms.updatePlugins(ctx); err != nil {
+		log.Error("error getting plugin info", err)
+		ms.err = errorMessage(err)
 	}
+	ms.refreshCompletions()
 
 	return textinput.Blink, nil
 }
 
 func (ms *mainScreen) Update(msg tea.Msg) (screen, tea.Cmd) {
+	defer ms.refreshCompletions()
+
 	switch msg := msg.(type) {
+	case metacommandResultMsg:
+		if ms.running == nil || msg.runID != ms.runID {
+			// the run was canceled
+			return ms, nil
+		}
+		r := ms.running
+		ms.stop()
+		if err := responseError(msg.resp, msg.err); err != nil {
+			log.Error("error executing metacommand", err,
+				"metacommand", r.name,
+			)
+			ms.failed = &metacommandError{run: r, err: err}
+			return ms, nil
+		}
+		ms.lastRun = r
+		if err := ms.showResult(msg); err != nil {
+			log.Error("error showing metacommand result", err,
+				"metacommand", r.name,
+			)
+			ms.failed = &metacommandError{run: r, err: err}
+		}
+		return ms, nil
+	case argValuesMsg:
+		if ms.running == nil || msg.runID != ms.runID {
+			// the completion was canceled
+			return ms, nil
+		}
+		ms.stop()
+		if msg.err != nil {
+			log.Error("error completing metacommand", msg.err)
+			ms.err = errorMessage(msg.err)
+			return ms, nil
+		}
+		ms.offerArgValues(msg.completion, msg.values)
+		return ms, nil
+	case pipelineInputMsg:
+		if ms.pending == nil {
+			return ms, nil
+		}
+		// the picked value is the last argument of the next stage
+		var (
+			stages = slices.Clone(ms.pending)
+			next   = stages[0]
+		)
+		stages[0] = &daemonproto.MetacommandRequest{
+			PluginName:  next.PluginName,
+			MetaCommand: next.MetaCommand,
+			Args:        append(slices.Clip(next.Args), msg.value),
+		}
+		return ms, ms.exec(ms.newRun(stages...))
+	case formSubmitMsg:
+		if ms.last == nil {
+			return ms, nil
+		}
+		// the metacommand that returned the form is called again with its values
+		r := ms.newRun(&daemonproto.MetacommandRequest{
+			PluginName:  ms.last.PluginName,
+			MetaCommand: ms.last.MetaCommand,
+			Args:        ms.last.Args,
+			FormValues:  msg.values,
+		})
+		r.format = msg.format
+		return ms, ms.exec(r)
+	case screenInitErrorMsg:
+		if ms.lastRun == nil {
+			ms.err = msg.err.Error()
+			return ms, nil
+		}
+		ms.failed = &metacommandError{
+			run: ms.lastRun,
+			err: fmt.Errorf("invalid response: %w", msg.err),
+		}
+		return ms, nil
+	case spinner.TickMsg:
+		if ms.running == nil {
+			return ms, nil
+		}
+		var cmd tea.Cmd
+		ms.spinner, cmd = ms.spinner.Update(msg)
+		return ms, cmd
 	case tea.KeyMsg:
-		switch key := msg.String(); key {
-		case "tab":
-			pn, mn, _ := ms.parsedInput()
-			ms.next("list_screen", listScreenInitData[[]list.Item]{
-				nextScreen: ms.Name(),
-				items:      ms.createCompletionList(pn, mn),
-			})
-
+		ms.err = ""
+		if ms.running != nil {
+			if key.Matches(msg, ms.keys.Back) {
+				ms.stop()
+				ms.err = "canceled"
+			}
 			return ms, nil
-		case "enter":
-			var pn, mn, args = ms.parsedInput()
-			if err := ms.execMetacommand(context.TODO(), pn, mn, args); err != nil {
-				log.Error("error executing metacommand", err)
+		}
+		if ms.failed != nil {
+			r := ms.failed.run
+			switch {
+			case key.Matches(msg, ms.keys.Execute):
+				ms.failed = nil
+				return ms, ms.exec(r)
+			case key.Matches(msg, ms.keys.Back):
+				ms.failed = nil
 			}
 			return ms, nil
 		}
+		if ms.searching && ms.updateSearch(msg) {
+			return ms, nil
+		}
+		switch {
+		case printable(msg):
+			// typed into the input, whatever action the key is bound to
+		case key.Matches(msg, ms.keys.HistoryPrev):
+			ms.recall(-1)
+			return ms, nil
+		case key.Matches(msg, ms.keys.HistoryNext):
+			ms.recall(1)
+			return ms, nil
+		case key.Matches(msg, ms.keys.Search):
+			ms.searching = true
+			ms.query = ""
+			ms.match = len(ms.history.entries)
+			ms.draft = ms.input.Value()
+			ms.input.Prompt = ms.searchPrompt()
+			return ms, nil
+		case key.Matches(msg, ms.keys.NextCompletion):
+			ms.moveSelection(1)
+			return ms, nil
+		case key.Matches(msg, ms.keys.PrevCompletion):
+			ms.moveSelection(-1)
+			return ms, nil
+		case key.Matches(msg, ms.keys.Copy):
+			return ms, copyText(ms.input.Value())
+		case key.Matches(msg, ms.keys.Complete):
+			if ms.acceptCompletion() {
+				return ms, nil
+			}
+			cmd, err := ms.complete()
+			if err != nil {
+				log.Error("error completing metacommand", err)
+				ms.err = errorMessage(err)
+			}
+			return ms, cmd
+		case key.Matches(msg, ms.keys.Execute):
+			stages, err := ms.parsedPipeline()
+			if err != nil {
+				log.Error("error executing metacommand", err)
+				ms.err = err.Error()
+				return ms, nil
+			}
+			if err := ms.history.add(ms.input.Value()); err != nil {
+				log.Error("error saving metamode history", err)
+			}
+			ms.histIdx = len(ms.history.entries)
+			return ms, ms.exec(ms.newRun(stages...))
+		}
 	}
 
 	var cmd tea.Cmd
@@ -88,94 +308,402 @@ func (ms *mainScreen) Update(msg tea.Msg) (screen, tea.Cmd) {
 }
 
 func (ms *mainScreen) View() string {
-	r := ms.input.View()
-	return inputBorder.Render(r)
-}
-
-func (ms *mainScreen) parsedInput() (string, string, []string) {
 	var (
-		topLevelParts = strings.Split(ms.input.Value(), " ")
-		parts         = strings.Split(topLevelParts[0], ms.pluginNameDelim)
-		args          = topLevelParts[1:]
-		plName        string
-		mcName        string
+		r    = ms.theme.input.Render(ms.inputView())
+		hint string
 	)
 
-	if 0 < len(parts) {
-		plName = parts[0]
+	if ms.running != nil {
+		hint = ms.theme.hint.Render(fmt.Sprintf("%s running %s  ",
+			ms.spinner.View(), ms.running.name,
+		) + ms.theme.shortHelp(relabeled(ms.keys.Back, "cancel")))
+	} else if ms.failed != nil {
+		hint = ms.errorDialog(ms.failed)
+	} else if ms.err != "" {
+		hint = ms.theme.error.Render(ms.err)
+	} else if 0 < len(ms.completions) {
+		hint = ms.completionsView()
+	} else if pn, mn, _, _, _ := ms.parsedInput(); ms.metacommands[pn][mn] != nil {
+		info := ms.metacommands[pn][mn]
+		hint = ms.theme.hint.Render(usage(pn+ms.pluginNameDelim+mn, info.Args))
+		if info.Description != "" {
+			hint += "\n" + ms.theme.hint.Render(info.Description)
+		}
 	}
 
-	if 1 < len(parts) {
-		mcName = parts[1]
+	if hint == "" {
+		return r
 	}
+	return lipgloss.JoinVertical(lipgloss.Left, r, hint)
+}
 
-	return plName, mcName, args
+func (ms *mainScreen) Keys() [][]key.Binding {
+	return [][]key.Binding{
+		{ms.keys.Execute, ms.keys.Complete, ms.keys.NextCompletion, ms.keys.PrevCompletion},
+		{ms.keys.HistoryPrev, ms.keys.HistoryNext, ms.keys.Search, ms.keys.Copy},
+	}
 }
 
-func (ms *mainScreen) createCompletionList(plugin, metacommand string) []list.Item {
-	var items = make([]list.Item, 0)
+// capturesKey keeps the keys typing into the input, and every key
+// during a history search, while a metacommand runs or while its
+// error is shown, from the global bindings.
+func (ms *mainScreen) capturesKey(msg tea.KeyMsg) bool {
+	return ms.searching || ms.running != nil || ms.failed != nil || printable(msg)
+}
 
-	for pluginName, mcNames := range ms.completionData {
-		if !strings.HasPrefix(pluginName, plugin) {
-			continue
-		}
-		for _, mcn := range mcNames {
-			if strings.HasPrefix(mcn, metacommand) {
-				items = append(items, &listableItem{
-					ItemTitle:       pluginName + " - " + mcn,
-					ItemDescription: "description",
-					ItemFilterValue: pluginName + "::" + mcn,
-					ItemValue:       pluginName + "::" + mcn,
-				})
-			}
+// recall shows the history entry delta entries away from the one being shown.
+func (ms *mainScreen) recall(delta int) {
+	idx := ms.histIdx + delta
+	if idx < 0 || len(ms.history.entries) < idx {
+		return
+	}
+
+	if ms.histIdx == len(ms.history.entries) {
+		ms.draft = ms.input.Value()
+	}
+	ms.histIdx = idx
+
+	if idx == len(ms.history.entries) {
+		ms.input.SetValue(ms.draft)
+	} else {
+		ms.input.SetValue(ms.history.entries[idx])
+	}
+	ms.input.CursorEnd()
+}
+
+// updateSearch handles msg during a reverse search, reporting whether it
+// was consumed. As in readline, the search key looks further back, ctrl+g
+// cancels the search and any other key ends it, keeping the match.
+func (ms *mainScreen) updateSearch(msg tea.KeyMsg) bool {
+	switch {
+	case key.Matches(msg, ms.keys.Search):
+		if idx := ms.history.search(ms.query, ms.match); idx != -1 {
+			ms.match = idx
+		}
+	case msg.Type == tea.KeyCtrlG:
+		ms.endSearch()
+		ms.input.SetValue(ms.draft)
+		ms.input.CursorEnd()
+		return true
+	case msg.Type == tea.KeyBackspace:
+		if ms.query == "" {
+			return true
 		}
+		_, size := utf8.DecodeLastRuneInString(ms.query)
+		ms.query = ms.query[:len(ms.query)-size]
+		ms.match = ms.history.search(ms.query, len(ms.history.entries))
+	case msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace:
+		ms.query += string(msg.Runes)
+		if msg.Type == tea.KeySpace {
+			ms.query += " "
+		}
+		ms.match = ms.history.search(ms.query, min(ms.match+1, len(ms.history.entries)))
+	default:
+		ms.endSearch()
+		return false
 	}
 
-	return items
+	ms.input.Prompt = ms.searchPrompt()
+	if 0 <= ms.match && ms.match < len(ms.history.entries) {
+		ms.input.SetValue(ms.history.entries[ms.match])
+		ms.input.CursorEnd()
+	}
+
+	return true
 }
 
-func (ms *mainScreen) execMetacommand(ctx context.Context, plugin, metacommand string, args []string) error {
-	var (
-		pf     = ms.formats[plugin]
-		format = pf[metacommand]
+func (ms *mainScreen) endSearch() {
+	ms.searching = false
+	ms.input.Prompt = ms.prompt
+	if 0 <= ms.match && ms.match < len(ms.history.entries) {
+		ms.histIdx = ms.match
+	}
+}
+
+func (ms *mainScreen) searchPrompt() string {
+	if ms.match < 0 {
+		return fmt.Sprintf("(failed reverse-i-search)`%s': ", ms.query)
+	}
+	return fmt.Sprintf("(reverse-i-search)`%s': ", ms.query)
+}
+
+// parsedInput splits the pipeline stage being typed into the plugin and
+// metacommand names and the metacommand's arguments. open reports whether
+// the last word is still being typed.
+func (ms *mainScreen) parsedInput() (plName, mcName string, args []string, open bool, err error) {
+	_, stage := ms.stageInput()
+	words, open, err := splitArgs(stage)
+	if len(words) == 0 {
+		return "", "", nil, open, err
+	}
+
+	plName, mcName, _ = strings.Cut(words[0], ms.pluginNameDelim)
 
-		req = daemonproto.MetacommandRequest{
+	return plName, mcName, words[1:], open, err
+}
+
+// complete completes the word being typed: the metacommand name, or the
+// value of the argument being typed if the metacommand has an argument
+// schema. A single candidate is completed in place; several are offered
+// in a list. The values of dynamic arguments are asked from the plugin
+// in the background, given the arguments typed so far.
+func (ms *mainScreen) complete() (tea.Cmd, error) {
+	pn, mn, args, open, _ := ms.parsedInput()
+	prefix, stage := ms.stageInput()
+	if len(args) == 0 && (open || stage == "") {
+		// metacommands are completed from the completions under the input
+		return nil, nil
+	}
+
+	info := ms.metacommands[pn][mn]
+	if info == nil {
+		return nil, fmt.Errorf("unknown metacommand %s%s%s", pn, ms.pluginNameDelim, mn)
+	}
+
+	var partial string
+	if open {
+		partial = args[len(args)-1]
+		args = args[:len(args)-1]
+	}
+	if len(info.Args) <= len(args) {
+		return nil, nil
+	}
+
+	// the line up to the word being completed, with its quoting normalized
+	line := prefix + pn + ms.pluginNameDelim + mn + " "
+	for _, a := range args {
+		line += quoteArg(a) + " "
+	}
+
+	c := argCompletion{arg: info.Args[len(args)], line: line, partial: partial}
+	switch {
+	case c.arg.Dynamic:
+		return ms.fetchArgValues(pn, mn, append(args, partial), c), nil
+	case c.arg.Type == daemonproto.MetacommandArgumentType_ENUM:
+		ms.offerArgValues(c, c.arg.EnumValues)
+	case c.arg.Type == daemonproto.MetacommandArgumentType_BOOL:
+		ms.offerArgValues(c, []string{"true", "false"})
+	}
+
+	return nil, nil
+}
+
+// argCompletion is the completion of the value of arg, typed after line
+// up to partial.
+type argCompletion struct {
+	arg     *daemonproto.MetacommandArgument
+	line    string
+	partial string
+}
+
+// argValuesMsg carries the values of a dynamic argument asked for
+// by the run runID.
+type argValuesMsg struct {
+	runID      int
+	completion argCompletion
+	values     []string
+	err        error
+}
+
+// fetchArgValues asks the plugin for the values of a dynamic argument in the
+// background, the way exec runs metacommands, delivering them as an
+// argValuesMsg.
+func (ms *mainScreen) fetchArgValues(plugin, metacommand string, args []string, c argCompletion) tea.Cmd {
+	ctx, cancel := context.WithTimeout(ms.ctx, ms.timeout)
+	ms.runID++
+	ms.running = &run{name: plugin + ms.pluginNameDelim + metacommand}
+	ms.cancel = cancel
+
+	var (
+		runID   = ms.runID
+		timeout = ms.timeout
+		req     = &daemonproto.MetacommandRequest{
 			PluginName:  plugin,
 			MetaCommand: metacommand,
+			Args:        args,
+			CompleteArg: c.arg.Name,
+			CommandLine: ms.commandLine.Text,
 		}
 	)
+	fetch := func() tea.Msg {
+		defer cancel()
+		msg := argValuesMsg{runID: runID, completion: c}
+		resp, err := ms.daemon.Metacommand(ctx, req)
+		msg.err = responseError(resp, err)
+		if msg.err == nil {
+			if err := json.Unmarshal(resp.Data, &msg.values); err != nil {
+				msg.err = fmt.Errorf("invalid completions for argument %s: %w", c.arg.Name, err)
+			}
+		}
+		if ctx.Err() == context.DeadlineExceeded {
+			msg.err = fmt.Errorf("completing %s timed out after %s", c.arg.Name, timeout)
+		}
+		return msg
+	}
+
+	return tea.Batch(fetch, ms.spinner.Tick)
+}
+
+// offerArgValues completes the value of the argument of c with those of
+// values starting with what has been typed of it.
+func (ms *mainScreen) offerArgValues(c argCompletion, values []string) {
+	var items = make([]list.Item, 0)
+	for _, v := range values {
+		if strings.HasPrefix(v, c.partial) {
+			items = append(items, &listableItem{
+				ItemTitle:       v,
+				ItemDescription: c.arg.Description,
+				ItemFilterValue: v,
+				ItemValue:       c.line + quoteArg(v) + " ",
+			})
+		}
+	}
+
+	switch len(items) {
+	case 0:
+	case 1:
+		ms.input.SetValue(items[0].(*listableItem).Value())
+		ms.input.CursorEnd()
+	default:
+		ms.next("list_screen", listScreenInitData[[]list.Item]{
+			nextScreen: ms.Name(),
+			items:      items,
+		})
+	}
+}
+
+// exec runs the stages of r in the background, a lone metacommand being
+// run as is, the result being delivered as a metacommandResultMsg.
+// The run is canceled if it takes longer than the timeout, if the user
+// cancels it or if metamode quits.
+func (ms *mainScreen) exec(r *run) tea.Cmd {
+	var (
+		stages = r.stages
+		last   = stages[len(stages)-1]
+		format = r.format
+		w, h   = ms.size()
+	)
+	if format == daemonproto.MetacommandResponseFormat_UNSPECIFIED {
+		format = ms.metacommands[last.PluginName][last.MetaCommand].GetFormat()
+	}
+	for _, stage := range stages {
+		stage.CommandLine = ms.commandLine.Text
+	}
 
 	switch format {
 	case daemonproto.MetacommandResponseFormat_SCREEN:
-		var w, h = ms.size()
-		req.FormatArgs = []string{fmt.Sprintf("size=%dx%d", w, h)}
+		last.FormatArgs = screenFormatArgs(w, h)
 	}
 
-	resp, err := ms.daemon.Metacommand(ctx, &req)
-	if err != nil {
-		return err
+	ctx, cancel := context.WithTimeout(ms.ctx, ms.timeout)
+	ms.runID++
+	ms.running = r
+	ms.cancel = cancel
+	ms.pending = nil
+
+	var (
+		runID   = ms.runID
+		timeout = ms.timeout
+	)
+	run := func() tea.Msg {
+		defer cancel()
+		msg := metacommandResultMsg{runID: runID, format: r.format, w: w, h: h}
+		if len(stages) == 1 {
+			msg.req = stages[0]
+			msg.resp, msg.err = ms.daemon.Metacommand(ctx, stages[0])
+		} else {
+			resp, err := ms.daemon.MetacommandPipeline(ctx, &daemonproto.MetacommandPipelineRequest{
+				Stages: stages,
+			})
+			if err == nil {
+				msg.req, msg.resp = resp.Request, resp.Response
+				msg.rest = stages[resp.Stage+1:]
+			}
+			msg.err = err
+		}
+		if ctx.Err() == context.DeadlineExceeded {
+			msg.err = fmt.Errorf("%s timed out after %s", r.name, timeout)
+		}
+		return msg
+	}
+
+	return tea.Batch(run, ms.spinner.Tick)
+}
+
+// stop cancels the metacommand being run, if any.
+func (ms *mainScreen) stop() {
+	if ms.cancel != nil {
+		ms.cancel()
+	}
+	ms.running = nil
+	ms.cancel = nil
+}
+
+// showResult moves on to the screen showing the result of a metacommand.
+func (ms *mainScreen) showResult(msg metacommandResultMsg) error {
+	var (
+		req    = msg.req
+		resp   = msg.resp
+		info   = ms.metacommands[req.PluginName][req.MetaCommand]
+		format = info.GetFormat()
+	)
+	if msg.format != daemonproto.MetacommandResponseFormat_UNSPECIFIED {
+		format = msg.format
 	}
+	ms.last = req
 
 	switch format {
 	case daemonproto.MetacommandResponseFormat_SHELL_INJECTION:
 		ms.next("shell_injection", string(resp.Data))
-	case daemonproto.MetacommandResponseFormat_SHELL_INJECTION_LIST:
-		ms.next("list_screen", listScreenInitData[[]byte]{
-			nextScreen: "shell_injection",
-			items:      resp.Data,
-		})
+	case daemonproto.MetacommandResponseFormat_SHELL_REPLACE:
+		if !ms.commandLine.Replaceable {
+			return errReplaceUnsupported
+		}
+		ms.next("shell_replace", string(resp.Data))
+	case daemonproto.MetacommandResponseFormat_SHELL_INJECTION_LIST, daemonproto.MetacommandResponseFormat_ITEM_LIST:
+		data := listScreenInitData[[]byte]{
+			items:              resp.Data,
+			plugin:             req.PluginName,
+			previewMetacommand: info.GetPreviewMetacommand(),
+		}
+		switch {
+		case 0 < len(msg.rest):
+			// the picked item is piped into the rest of the pipeline
+			ms.pending = msg.rest
+			data.nextScreen = "pipeline"
+			data.multiSelect, data.separator = info.GetMultiSelect(), info.GetSeparator()
+		case format == daemonproto.MetacommandResponseFormat_SHELL_INJECTION_LIST:
+			data.nextScreen = "shell_injection"
+			data.multiSelect, data.separator = info.GetMultiSelect(), info.GetSeparator()
+		}
+		// without a next screen the list can only be browsed
+		ms.next("list_screen", data)
 	case daemonproto.MetacommandResponseFormat_SCREEN:
-		ms.next("fullscreen", string(resp.Data))
+		ms.next("fullscreen", fullscreenInitData{
+			req:     req,
+			content: string(resp.Data),
+			w:       msg.w,
+			h:       msg.h,
+		})
+	case daemonproto.MetacommandResponseFormat_TEXT:
+		ms.next("text_screen", textScreenInitData{
+			title: req.PluginName + ms.pluginNameDelim + req.MetaCommand,
+			text:  string(resp.Data),
+		})
+	case daemonproto.MetacommandResponseFormat_FORM:
+		ms.next("form_screen", formScreenInitData{
+			title: req.PluginName + ms.pluginNameDelim + req.MetaCommand,
+			data:  resp.Data,
+		})
 	default:
 		// TODO(raphaelreyna): add remaining formats
 		log.Warn("unknown or unimplemented metacommand response format",
-			"plugin", plugin,
-			"metacommand", metacommand,
+			"plugin", req.PluginName,
+			"metacommand", req.MetaCommand,
 			"format", format,
 			"data", string(resp.Data),
 		)
 	}
-
 	return nil
 }
 
@@ -190,15 +718,15 @@ func (ms *mainScreen) updatePlugins(ctx context.Context) error {
 
 	for _, plugin := range resp.Plugins {
 		var (
-			mcs     = make([]string, len(plugin.Metacommands))
-			formats = make(map[string]daemonproto.MetacommandResponseFormat)
+			mcs   = make([]string, len(plugin.Metacommands))
+			infos = make(map[string]*daemonproto.MetacommandInfo)
 		)
 		for idx, mc := range plugin.Metacommands {
 			mcs[idx] = mc.Name
-			formats[mc.Name] = mc.Format
+			infos[mc.Name] = mc
 		}
 		ms.completionData[plugin.Name] = mcs
-		ms.formats[plugin.Name] = formats
+		ms.metacommands[plugin.Name] = infos
 	}
 
 	return nil
@@ -0,0 +1,103 @@
+package metamode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	daemonproto "github.com/raphaelreyna/metashell/internal/rpc/go/daemon"
+)
+
+// run is a metacommand, or a pipeline of metacommands, to be run.
+// format, if set, overrides the format of the last metacommand.
+type run struct {
+	name   string
+	stages []*daemonproto.MetacommandRequest
+	format daemonproto.MetacommandResponseFormat
+}
+
+// pipelineInputMsg carries the value picked from a list returned
+// midway through a pipeline, to be piped into its next stage.
+type pipelineInputMsg struct {
+	value string
+}
+
+// stageInput splits the input into the pipeline stage being typed and
+// what comes before it, up to the blanks after the last '|'.
+func (ms *mainScreen) stageInput() (prefix, stage string) {
+	var (
+		value  = ms.input.Value()
+		stages = splitPipeline(value)
+	)
+	if len(stages) == 1 {
+		return "", value
+	}
+
+	stage = strings.TrimLeft(stages[len(stages)-1], " \t")
+	return value[:len(value)-len(stage)], stage
+}
+
+// parsedPipeline parses the input into the requests for the stages of the
+// pipeline it describes, a lone metacommand being a pipeline of one stage.
+// Every stage but the first gets the data returned by the previous one as
+// its last argument.
+func (ms *mainScreen) parsedPipeline() ([]*daemonproto.MetacommandRequest, error) {
+	var (
+		segments = splitPipeline(ms.input.Value())
+		stages   = make([]*daemonproto.MetacommandRequest, len(segments))
+	)
+	for idx, segment := range segments {
+		words, _, err := splitArgs(segment)
+		if err != nil {
+			return nil, err
+		}
+		if len(words) == 0 {
+			if len(segments) == 1 {
+				return nil, errors.New("missing metacommand")
+			}
+			return nil, fmt.Errorf("missing metacommand in stage %d of the pipeline", idx+1)
+		}
+
+		var (
+			pn, mn, _ = strings.Cut(words[0], ms.pluginNameDelim)
+			info      = ms.metacommands[pn][mn]
+			schema    = info.GetArgs()
+			args      = words[1:]
+		)
+		if idx < len(segments)-1 && info.GetFormat() == daemonproto.MetacommandResponseFormat_FORM {
+			return nil, fmt.Errorf("%s returns a form and can only end a pipeline", words[0])
+		}
+		if idx == 0 {
+			err = validateArgs(schema, args)
+		} else {
+			err = validatePipedArgs(schema, args)
+		}
+		if err != nil {
+			if 1 < len(segments) {
+				err = fmt.Errorf("%s: %w", words[0], err)
+			}
+			return nil, err
+		}
+
+		stages[idx] = &daemonproto.MetacommandRequest{
+			PluginName:  pn,
+			MetaCommand: mn,
+			Args:        args,
+		}
+	}
+
+	return stages, nil
+}
+
+func (ms *mainScreen) newRun(stages ...*daemonproto.MetacommandRequest) *run {
+	return &run{name: ms.pipelineName(stages), stages: stages}
+}
+
+// pipelineName names the pipeline of stages the way it is typed, e.g. "git::branches | logging::filter".
+func (ms *mainScreen) pipelineName(stages []*daemonproto.MetacommandRequest) string {
+	names := make([]string, len(stages))
+	for idx, stage := range stages {
+		names[idx] = stage.PluginName + ms.pluginNameDelim + stage.MetaCommand
+	}
+	return strings.Join(names, " | ")
+}
@@ -0,0 +1,101 @@
+package metamode
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raphaelreyna/metashell/internal/log"
+	daemonproto "github.com/raphaelreyna/metashell/internal/rpc/go/daemon"
+)
+
+// previewFrameWidth is the width taken by the border and padding of the preview pane.
+const previewFrameWidth = 2
+
+// listPreview is the preview of a list item, fetched from its plugin.
+type listPreview struct {
+	text string
+	err  error
+	done bool
+}
+
+// previewMsg carries the preview fetched for item.
+type previewMsg struct {
+	item    *listableItem
+	preview listPreview
+}
+
+// splitWidths splits a screen w wide between the list and its preview pane.
+func splitWidths(w int) (list, preview int) {
+	list = w / 2
+	return list, w - list
+}
+
+// fetchPreview fetches the preview of the selected item from the
+// plugin's preview metacommand, unless it has been fetched already.
+func (s *listScreen) fetchPreview() tea.Cmd {
+	item, ok := s.l.SelectedItem().(*listableItem)
+	if !ok || item == nil || item.ItemPreview != "" || s.previewMetacommand == "" {
+		return nil
+	}
+	if _, ok := s.previews[item]; ok {
+		return nil
+	}
+	s.previews[item] = &listPreview{}
+
+	var (
+		w, h  = s.size()
+		_, pw = splitWidths(w)
+		arg   = item.Value()
+	)
+	req := daemonproto.MetacommandRequest{
+		PluginName:  s.plugin,
+		MetaCommand: s.previewMetacommand,
+		Args:        []string{arg},
+		FormatArgs:  []string{fmt.Sprintf("size=%dx%d", max(pw-previewFrameWidth, 0), h)},
+		CommandLine: s.commandLine,
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
+		defer cancel()
+
+		msg := previewMsg{item: item, preview: listPreview{done: true}}
+		resp, err := s.daemon.Metacommand(ctx, &req)
+		if err := responseError(resp, err); err != nil {
+			log.Error("error fetching preview", err,
+				"plugin", req.PluginName,
+				"metacommand", req.MetaCommand,
+			)
+			msg.preview.err = err
+			return msg
+		}
+		msg.preview.text = string(resp.Data)
+		return msg
+	}
+}
+
+// previewView renders the preview of the selected item in a pane w by h,
+// cutting off what does not fit rather than wrapping it.
+func (s *listScreen) previewView(w, h int) string {
+	var text string
+	if item, ok := s.l.SelectedItem().(*listableItem); ok && item != nil {
+		switch p := s.previews[item]; {
+		case item.ItemPreview != "":
+			text = item.ItemPreview
+		case p == nil:
+		case p.err != nil:
+			text = s.theme.error.Render(errorMessage(p.err))
+		case !p.done:
+			text = s.theme.hint.Render("loading…")
+		default:
+			text = p.text
+		}
+	}
+
+	return s.theme.preview.Copy().
+		Height(h).
+		MaxHeight(h).
+		MaxWidth(w).
+		Render(text)
+}
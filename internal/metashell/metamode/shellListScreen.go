@@ -1,10 +1,16 @@
 package metamode
 
 import (
+	"context"
 	"encoding/json"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	daemonproto "github.com/raphaelreyna/metashell/internal/rpc/go/daemon"
 )
 
 type listableItem struct {
@@ -12,12 +18,38 @@ type listableItem struct {
 	ItemDescription string `json:"description"`
 	ItemFilterValue string `json:"filter_value"`
 	ItemValue       any    `json:"value,omitempty"`
+	ItemPreview     string `json:"preview,omitempty"`
+
+	// checkbox is whether the item can be marked, in a multi-select list.
+	checkbox bool
+	marked   bool
+}
+
+func (l *listableItem) Title() string {
+	switch {
+	case !l.checkbox:
+		return l.ItemTitle
+	case l.marked:
+		return "[x] " + l.ItemTitle
+	}
+	return "[ ] " + l.ItemTitle
 }
 
-func (l *listableItem) Title() string       { return l.ItemTitle }
 func (l *listableItem) Description() string { return l.ItemDescription }
 func (l *listableItem) FilterValue() string { return l.ItemFilterValue }
-func (l *listableItem) Value() any          { return l.ItemValue }
+
+// Value is what selecting the item passes on: its value, or its title if it has none.
+// Values that are not strings are passed on as JSON.
+func (l *listableItem) Value() string {
+	switch v := l.ItemValue.(type) {
+	case nil:
+		return l.ItemTitle
+	case string:
+		return v
+	}
+	b, _ := json.Marshal(l.ItemValue)
+	return string(b)
+}
 
 type listData interface {
 	[]list.Item | []byte
@@ -26,13 +58,34 @@ type listData interface {
 type listScreenInitData[D listData] struct {
 	items      D
 	nextScreen string
+	// multiSelect lets several items be marked, the values
+	// of which are joined with separator when selected.
+	multiSelect bool
+	separator   string
+	// previewMetacommand, a metacommand of plugin, previews the selected item.
+	plugin             string
+	previewMetacommand string
 }
 
 type listScreen struct {
 	nextScreenName string
+	multiSelect    bool
+	separator      string
 	next           func(string, any)
 	size           func() (int, int)
+	keys           *keymap
+	theme          *theme
 	l              list.Model
+
+	// split is whether the list shares the screen with a preview pane.
+	split              bool
+	plugin             string
+	previewMetacommand string
+	previews           map[*listableItem]*listPreview
+	daemon             daemonproto.MetashellDaemonClient
+	ctx                context.Context
+	timeout            time.Duration
+	commandLine        string
 }
 
 func (s *listScreen) Name() string {
@@ -42,18 +95,26 @@ func (s *listScreen) Name() string {
 func (s *listScreen) Init(rs rootScreen, data any) (tea.Cmd, error) {
 	var (
 		w, h      = rs.size()
-		d         = list.NewDefaultDelegate()
+		d         = rs.theme().listDelegate()
 		listItems []list.Item
 	)
 
-	d.UpdateFunc = s.delegatedUpdate
 	s.next = rs.next
 	s.size = rs.size
+	s.keys = rs.keys()
+	s.theme = rs.theme()
+	s.daemon = rs.daemon()
+	s.ctx = rs.ctx()
+	s.timeout = rs.config().Timeout
+	s.commandLine = rs.commandLine().Text
+	s.previews = make(map[*listableItem]*listPreview)
+	s.plugin, s.previewMetacommand = "", ""
 
 	switch data := data.(type) {
 	case listScreenInitData[[]list.Item]:
 		listItems = data.items
 		s.nextScreenName = data.nextScreen
+		s.multiSelect, s.separator = data.multiSelect, data.separator
 	case listScreenInitData[[]byte]:
 		var items []*listableItem
 		if err := json.Unmarshal(data.items, &items); err != nil {
@@ -62,38 +123,130 @@ func (s *listScreen) Init(rs rootScreen, data any) (tea.Cmd, error) {
 
 		listItems = make([]list.Item, len(items))
 		for i := 0; i < len(items); i++ {
+			items[i].checkbox = data.multiSelect
 			listItems[i] = items[i]
 		}
 
 		s.nextScreenName = data.nextScreen
+		s.multiSelect, s.separator = data.multiSelect, data.separator
+		s.plugin, s.previewMetacommand = data.plugin, data.previewMetacommand
+	}
+	s.split = s.previewMetacommand != ""
+	for _, i := range listItems {
+		if item, ok := i.(*listableItem); ok && item.ItemPreview != "" {
+			s.split = true
+		}
+	}
+	if s.separator == "" {
+		s.separator = " "
+	}
+
+	itemKeys := []key.Binding{s.keys.Select, s.keys.Copy}
+	if s.multiSelect {
+		itemKeys = append(itemKeys, s.keys.MultiSelect)
+	}
+	d.UpdateFunc = s.delegatedUpdate
+	d.ShortHelpFunc = func() []key.Binding {
+		return itemKeys
+	}
+	d.FullHelpFunc = func() [][]key.Binding {
+		return [][]key.Binding{itemKeys}
 	}
 
 	s.l = list.New(listItems, d, w, h)
+	s.l.Styles = rs.theme().list
+	// quitting, going back and the help are left to the Handler
+	s.l.KeyMap.Quit.SetEnabled(false)
+	s.l.KeyMap.ForceQuit.SetEnabled(false)
+	s.l.KeyMap.ShowFullHelp.SetEnabled(false)
+	s.l.KeyMap.CloseFullHelp.SetEnabled(false)
+	s.l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{s.keys.Back, s.keys.Help}
+	}
 
-	return nil, nil
+	return s.fetchPreview(), nil
 }
 
 func (s *listScreen) Update(msg tea.Msg) (screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if s.l.FilterState() != list.Filtering && key.Matches(msg, s.keys.Copy) {
+			return s, s.copySelected()
+		}
+	case previewMsg:
+		s.previews[msg.item] = &msg.preview
+		return s, nil
+	}
+
 	var cmd tea.Cmd
 	s.l, cmd = s.l.Update(msg)
-	return s, cmd
+	return s, tea.Batch(cmd, s.fetchPreview())
 }
 
 func (s *listScreen) View() string {
 	var w, h = s.size()
-	s.l.SetSize(w, h)
-	return s.l.View()
+	if !s.split {
+		s.l.SetSize(w, h)
+		return s.l.View()
+	}
+
+	lw, pw := splitWidths(w)
+	s.l.SetSize(lw, h)
+	// pad every line to the full width so that the panes are not centered line by line
+	return lipgloss.NewStyle().Width(w).Render(lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(lw).Render(s.l.View()),
+		s.previewView(pw, h),
+	))
+}
+
+func (s *listScreen) Keys() [][]key.Binding {
+	return s.l.FullHelp()
+}
+
+// capturesKey keeps the keys typing a filter, and the one clearing
+// an applied filter, from the global bindings.
+func (s *listScreen) capturesKey(msg tea.KeyMsg) bool {
+	switch s.l.FilterState() {
+	case list.Filtering:
+		return true
+	case list.FilterApplied:
+		return key.Matches(msg, s.l.KeyMap.ClearFilter)
+	}
+	return false
+}
+
+// copySelected copies the values of the marked items or else the value
+// of the selected item, or its title if its value is not text.
+func (s *listScreen) copySelected() tea.Cmd {
+	if values := s.markedValues(&s.l); 0 < len(values) {
+		return copyText(strings.Join(values, s.separator))
+	}
+	item, ok := s.l.SelectedItem().(*listableItem)
+	if !ok || item == nil {
+		return nil
+	}
+	return copyText(item.Value())
 }
 
 func (s *listScreen) delegatedUpdate(msg tea.Msg, model *list.Model) tea.Cmd {
 	type forwardableItem interface {
-		Value() any
+		Value() string
 	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "enter":
+		switch {
+		case s.multiSelect && key.Matches(msg, s.keys.MultiSelect):
+			if item, ok := model.SelectedItem().(*listableItem); ok && item != nil {
+				item.marked = !item.marked
+				model.CursorDown()
+			}
+		case key.Matches(msg, s.keys.Select):
+			if values := s.markedValues(model); s.nextScreenName != "" && 0 < len(values) {
+				s.next(s.nextScreenName, strings.Join(values, s.separator))
+				return nil
+			}
+
 			var (
 				selectedItem = model.SelectedItem()
 				item, ok     = selectedItem.(forwardableItem)
@@ -107,3 +260,14 @@ func (s *listScreen) delegatedUpdate(msg tea.Msg, model *list.Model) tea.Cmd {
 
 	return nil
 }
+
+// markedValues returns the values of the marked items, in list order.
+func (s *listScreen) markedValues(model *list.Model) []string {
+	var values []string
+	for _, i := range model.Items() {
+		if item, ok := i.(*listableItem); ok && item.marked {
+			values = append(values, item.Value())
+		}
+	}
+	return values
+}
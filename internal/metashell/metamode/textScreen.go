@@ -0,0 +1,92 @@
+package metamode
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type textScreenInitData struct {
+	title string
+	text  string
+}
+
+// textScreen is a scrollable, read-only view of a metacommand's text output.
+type textScreen struct {
+	title string
+	text  string
+
+	next  func(string, any)
+	size  func() (int, int)
+	theme *theme
+	keys  *keymap
+	vp    viewport.Model
+}
+
+func (s *textScreen) Name() string {
+	return "text_screen"
+}
+
+func (s *textScreen) Init(rs rootScreen, data any) (tea.Cmd, error) {
+	initData, _ := data.(textScreenInitData)
+
+	s.next = rs.next
+	s.size = rs.size
+	s.theme = rs.theme()
+	s.keys = rs.keys()
+	s.title = initData.title
+	s.text = initData.text
+
+	s.vp = viewport.New(0, 0)
+	s.resize()
+
+	return nil, nil
+}
+
+func (s *textScreen) Update(msg tea.Msg) (screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if key.Matches(msg, s.keys.Copy) {
+			return s, copyText(s.text)
+		}
+	case tea.WindowSizeMsg:
+		s.resize()
+	}
+
+	var cmd tea.Cmd
+	s.vp, cmd = s.vp.Update(msg)
+	return s, cmd
+}
+
+func (s *textScreen) View() string {
+	footer := fmt.Sprintf("%3.f%%  ", s.vp.ScrollPercent()*100) +
+		s.theme.shortHelp(s.keys.Back, s.keys.Copy, s.keys.Help)
+	return lipgloss.JoinVertical(lipgloss.Left,
+		s.theme.title.Render(s.title),
+		s.vp.View(),
+		s.theme.footer.Render(footer),
+	)
+}
+
+func (s *textScreen) Keys() [][]key.Binding {
+	km := s.vp.KeyMap
+	return [][]key.Binding{
+		{km.Up, km.Down, km.PageUp, km.PageDown},
+		{km.HalfPageUp, km.HalfPageDown, s.keys.Copy},
+	}
+}
+
+// resize fits the viewport between the title and the footer,
+// wrapping the text to the new width.
+func (s *textScreen) resize() {
+	w, h := s.size()
+	s.vp.Width = w
+	s.vp.Height = h - 2
+	if s.vp.Height < 0 {
+		s.vp.Height = 0
+	}
+	s.vp.SetContent(s.theme.text.Copy().Width(w).Render(s.text))
+}
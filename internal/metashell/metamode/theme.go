@@ -0,0 +1,259 @@
+package metamode
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Colors are lipgloss colors, either ANSI color numbers such as "63" or hex
+// codes such as "#7D56F4". Empty colors are left to the terminal or to the
+// defaults of the component being styled.
+type Colors struct {
+	Text     string `yaml:"text"`
+	Muted    string `yaml:"muted"`
+	Accent   string `yaml:"accent"`
+	Border   string `yaml:"border"`
+	Selected string `yaml:"selected"`
+	Error    string `yaml:"error"`
+}
+
+type ThemeConfig struct {
+	// Name is the built-in theme to start from; the other
+	// fields override the parts of it that are set.
+	Name   string `yaml:"name"`
+	Prompt string `yaml:"prompt"`
+	// Border is one of normal, rounded, thick, double or hidden.
+	Border string `yaml:"border"`
+	Colors Colors `yaml:"colors"`
+}
+
+var themes = map[string]ThemeConfig{
+	"default": {
+		Prompt: "> ",
+		Border: "normal",
+		Colors: Colors{
+			Accent: "63",
+			Border: "63",
+			Error:  "9",
+		},
+	},
+	"dark": {
+		Prompt: "❯ ",
+		Border: "rounded",
+		Colors: Colors{
+			Text:     "#FAFAFA",
+			Muted:    "#777777",
+			Accent:   "#7D56F4",
+			Border:   "#7D56F4",
+			Selected: "#EE6FF8",
+			Error:    "#FF5F87",
+		},
+	},
+	"light": {
+		Prompt: "❯ ",
+		Border: "rounded",
+		Colors: Colors{
+			Text:     "#1A1A1A",
+			Muted:    "#6B6B6B",
+			Accent:   "#5A3FC0",
+			Border:   "#5A3FC0",
+			Selected: "#A3209D",
+			Error:    "#C0002A",
+		},
+	},
+}
+
+var borders = map[string]lipgloss.Border{
+	"normal":  lipgloss.NormalBorder(),
+	"rounded": lipgloss.RoundedBorder(),
+	"thick":   lipgloss.ThickBorder(),
+	"double":  lipgloss.DoubleBorder(),
+	"hidden":  lipgloss.HiddenBorder(),
+}
+
+// theme holds the styles of every screen hosted by the Handler.
+type theme struct {
+	prompt string
+
+	input       lipgloss.Style
+	text        lipgloss.Style
+	hint        lipgloss.Style
+	error       lipgloss.Style
+	ghost       lipgloss.Style
+	selected    lipgloss.Style
+	description lipgloss.Style
+	title       lipgloss.Style
+	footer      lipgloss.Style
+	dialog      lipgloss.Style
+	dialogTitle lipgloss.Style
+	preview     lipgloss.Style
+
+	listItems list.DefaultItemStyles
+	list      list.Styles
+	help      help.Styles
+}
+
+func newTheme(c ThemeConfig) (*theme, error) {
+	name := c.Name
+	if name == "" {
+		name = "default"
+	}
+	tc, ok := themes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown theme %q (built-in themes: %s)", name, strings.Join(sortedKeys(themes), ", "))
+	}
+
+	if c.Prompt != "" {
+		tc.Prompt = c.Prompt
+	}
+	if c.Border != "" {
+		tc.Border = c.Border
+	}
+	for _, o := range []struct{ dst, src *string }{
+		{&tc.Colors.Text, &c.Colors.Text},
+		{&tc.Colors.Muted, &c.Colors.Muted},
+		{&tc.Colors.Accent, &c.Colors.Accent},
+		{&tc.Colors.Border, &c.Colors.Border},
+		{&tc.Colors.Selected, &c.Colors.Selected},
+		{&tc.Colors.Error, &c.Colors.Error},
+	} {
+		if *o.src != "" {
+			*o.dst = *o.src
+		}
+	}
+
+	border, ok := borders[tc.Border]
+	if !ok {
+		return nil, fmt.Errorf("unknown border %q", tc.Border)
+	}
+
+	var (
+		colors = tc.Colors
+		muted  = fg(lipgloss.NewStyle(), colors.Muted)
+		t      = theme{prompt: tc.Prompt}
+	)
+	if colors.Muted == "" {
+		muted = muted.Faint(true)
+	}
+	selected := colors.Selected
+	if selected == "" {
+		selected = colors.Accent
+	}
+
+	t.text = fg(lipgloss.NewStyle(), colors.Text)
+	t.input = fg(lipgloss.NewStyle(), colors.Text).
+		BorderStyle(border).
+		Padding(1, 2)
+	if colors.Border != "" {
+		t.input = t.input.BorderForeground(lipgloss.Color(colors.Border))
+	}
+	t.hint = muted.Copy().Padding(0, 1)
+	t.error = fg(lipgloss.NewStyle(), colors.Error).Padding(0, 1)
+	t.ghost = muted.Copy()
+	t.selected = fg(lipgloss.NewStyle(), selected).Bold(true)
+	t.description = muted.Copy()
+	t.title = fg(lipgloss.NewStyle(), colors.Accent).Bold(true).Padding(0, 1)
+	t.footer = muted.Copy().Padding(0, 1)
+	t.dialog = lipgloss.NewStyle().BorderStyle(border).Padding(0, 1)
+	if colors.Error != "" {
+		t.dialog = t.dialog.BorderForeground(lipgloss.Color(colors.Error))
+	}
+	t.dialogTitle = fg(lipgloss.NewStyle(), colors.Error).Bold(true)
+	t.preview = lipgloss.NewStyle().
+		BorderStyle(border).
+		BorderTop(false).
+		BorderRight(false).
+		BorderBottom(false).
+		BorderLeft(true).
+		PaddingLeft(1)
+	if colors.Border != "" {
+		t.preview = t.preview.BorderForeground(lipgloss.Color(colors.Border))
+	}
+
+	t.listItems = list.NewDefaultItemStyles()
+	if colors.Text != "" {
+		t.listItems.NormalTitle = fg(t.listItems.NormalTitle, colors.Text)
+	}
+	if colors.Muted != "" {
+		t.listItems.NormalDesc = fg(t.listItems.NormalDesc, colors.Muted)
+		t.listItems.DimmedTitle = fg(t.listItems.DimmedTitle, colors.Muted)
+		t.listItems.DimmedDesc = fg(t.listItems.DimmedDesc, colors.Muted)
+	}
+	if colors.Selected != "" {
+		c := lipgloss.Color(colors.Selected)
+		t.listItems.SelectedTitle = t.listItems.SelectedTitle.Foreground(c).BorderForeground(c)
+		t.listItems.SelectedDesc = t.listItems.SelectedDesc.Foreground(c).BorderForeground(c)
+	}
+
+	t.list = list.DefaultStyles()
+	if colors.Accent != "" {
+		t.list.Title = t.list.Title.Background(lipgloss.Color(colors.Accent))
+	}
+	if colors.Muted != "" {
+		t.list.HelpStyle = fg(t.list.HelpStyle, colors.Muted)
+		t.list.StatusBar = fg(t.list.StatusBar, colors.Muted)
+	}
+
+	t.help = help.New().Styles
+	t.help.ShortKey = t.text.Copy().Bold(true)
+	t.help.FullKey = t.help.ShortKey.Copy()
+	t.help.ShortDesc = muted.Copy()
+	t.help.FullDesc = muted.Copy()
+	t.help.ShortSeparator = muted.Copy()
+	t.help.FullSeparator = muted.Copy()
+
+	return &t, nil
+}
+
+// listDelegate returns a list item delegate styled by the theme.
+func (t *theme) listDelegate() list.DefaultDelegate {
+	d := list.NewDefaultDelegate()
+	d.Styles = t.listItems
+	return d
+}
+
+// shortHelp renders bindings on a single line.
+func (t *theme) shortHelp(bindings ...key.Binding) string {
+	h := help.New()
+	h.Styles = t.help
+	return h.ShortHelpView(bindings)
+}
+
+// fullHelp renders groups of bindings in columns, wrapping
+// the columns that do not fit in width onto new rows.
+func (t *theme) fullHelp(groups [][]key.Binding, width int) string {
+	h := help.New()
+	h.Styles = t.help
+	h.Width = width
+
+	var rows, row []string
+	for _, g := range groups {
+		col := h.FullHelpView([][]key.Binding{g})
+		if col == "" {
+			continue
+		}
+		col = lipgloss.NewStyle().PaddingRight(4).Render(col)
+		if 0 < len(row) && width < lipgloss.Width(lipgloss.JoinHorizontal(lipgloss.Top, append(row, col)...)) {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row = nil
+		}
+		row = append(row, col)
+	}
+	if 0 < len(row) {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+func fg(s lipgloss.Style, color string) lipgloss.Style {
+	if color == "" {
+		return s
+	}
+	return s.Foreground(lipgloss.Color(color))
+}
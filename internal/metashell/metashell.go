@@ -1,16 +1,22 @@
 package metashell
 
 import (
-	"bufio"
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"os/signal"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/creack/pty"
@@ -22,35 +28,69 @@ import (
 	"github.com/raphaelreyna/metashell/internal/log"
 	"github.com/raphaelreyna/metashell/internal/metashell/metamode"
 	daemonproto "github.com/raphaelreyna/metashell/internal/rpc/go/daemon"
+	"github.com/raphaelreyna/metashell/internal/shell"
 )
 
 type MetaShell struct {
-	config Config
+	config  Config
+	dialect shell.Dialect
 
 	cmd           *exec.Cmd
 	ptmx          *os.File
 	sigChan       chan os.Signal
 	originalState *unix.Termios
 
-	grpcConn *grpc.ClientConn
-	client   daemonproto.MetashellDaemonClient
-	ecStream daemonproto.MetashellDaemon_NewExitCodeStreamClient
+	grpcConn    *grpc.ClientConn
+	client      daemonproto.MetashellDaemonClient
+	shellclient daemonproto.ShellclientDaemonClient
+	ecStream    daemonproto.MetashellDaemon_NewExitCodeStreamClient
 
 	doneChan  chan error
 	cancelCtx func()
 
-	tty string
+	tty        string
+	session    string
+	seq        int
+	pendingKey string
 
-	in        io.Reader
+	in        *os.File
 	out       *os.File
 	cmdBuffer string
-	scanner   *bufio.Scanner
+	// cmdBufferStale is whether a key cmdBuffer cannot keep track of,
+	// such as an arrow key or tab, was typed since the line was known.
+	cmdBufferStale bool
+	lastLine       string
+	leader         [][]byte
+	// lines receives the command lines reported by the hooks.
+	lines chan string
+	// outputAt is when the shell last wrote to the terminal, in Unix nanoseconds.
+	outputAt atomic.Int64
 
 	cmdIsRunning bool
+	altScreen    bool
+	// innerPaste is whether the program running in the PTY asked
+	// for bracketed paste; it is always on for the outer terminal.
+	innerPaste bool
+
+	// reports holds the reports of the command lifecycle
+	// waiting to be sent to the daemon.
+	reports chan func(context.Context)
 
 	sync.RWMutex
 }
 
+const (
+	// maxPendingReports is the number of reports to the daemon that
+	// can wait to be sent before new ones are dropped.
+	maxPendingReports = 64
+	reportTimeout     = 5 * time.Second
+	// lineTimeout is how long the shell is given to report the line being typed.
+	lineTimeout = 500 * time.Millisecond
+	// redrawPause is how long the shell has to go without writing for it
+	// to be taken to be done redrawing the line after reporting it.
+	redrawPause = 30 * time.Millisecond
+)
+
 func (ms *MetaShell) stop() {
 	ms.cancelCtx()
 
@@ -64,6 +104,7 @@ func (ms *MetaShell) stop() {
 		x.Close()
 	}
 
+	io.WriteString(os.Stdout, bracketedPasteOff)
 	restoreTTYSettings(int(os.Stdin.Fd()), ms.originalState)
 
 	ms.doneChan <- nil
@@ -72,7 +113,32 @@ func (ms *MetaShell) stop() {
 func (ms *MetaShell) Run(ctx context.Context) error {
 	ctx, ms.cancelCtx = context.WithCancel(ctx)
 
-	err := ms.ensureDaemon(ctx)
+	var err error
+	ms.dialect, err = ms.config.Dialect()
+	if err != nil {
+		log.Error("error determining shell dialect", err)
+		return err
+	}
+
+	switch ms.config.Delineation {
+	case DelineationHooks, DelineationOSC133:
+	default:
+		err = fmt.Errorf("unknown delineation mode: %s", ms.config.Delineation)
+		log.Error("error validating config", err)
+		return err
+	}
+
+	ms.leader, err = parseLeader(ms.config.Leader)
+	if err != nil {
+		log.Error("error validating config", err)
+		return err
+	}
+	if err := ms.config.Metamode.Validate(); err != nil {
+		log.Error("error validating config", err)
+		return err
+	}
+
+	err = ms.ensureDaemon(ctx)
 	if err != nil {
 		log.Error("error ensuring daemon", err)
 		return err
@@ -87,15 +153,24 @@ func (ms *MetaShell) Run(ctx context.Context) error {
 	}
 	defer ms.grpcConn.Close()
 
+	ms.session, err = newSessionNonce()
+	if err != nil {
+		log.Error("error creating session nonce", err)
+		return err
+	}
+
 	ms.cmd = exec.CommandContext(ctx, ms.config.ShellPath)
+	ms.cmd.Env = append(os.Environ(), shell.SessionEnv+"="+ms.session)
 	ptmx, err := pty.Start(ms.cmd)
 	if err != nil {
 		log.Error("error starting pty", err)
 		return err
 	}
+	ms.ptmx = ptmx
 	ms.tty = ms.cmd.Stdin.(*os.File).Name()
 
 	ms.client = daemonproto.NewMetashellDaemonClient(ms.grpcConn)
+	ms.shellclient = daemonproto.NewShellclientDaemonClient(ms.grpcConn)
 
 	header := metadata.New(map[string]string{"TTY": ms.tty})
 	ms.ecStream, err = ms.client.NewExitCodeStream(
@@ -156,79 +231,381 @@ func (ms *MetaShell) Run(ctx context.Context) error {
 		log.Error("error setting tty", err)
 		return err
 	}
+	io.WriteString(os.Stdout, bracketedPasteOn)
 
 	ms.in = os.Stdin
 	ms.out = ptmx
+	ms.reports = make(chan func(context.Context), maxPendingReports)
+	ms.lines = make(chan string, 1)
 
 	go ms.start(ctx)
-	go func() { _, _ = io.Copy(os.Stdout, ptmx) }()
+	go ms.sendReports(ctx)
+	go func() {
+		_, _ = io.Copy(&outputFilter{
+			out: os.Stdout,
+			onOSC: func(payload string) bool {
+				code, params, _ := strings.Cut(payload, ";")
+				switch {
+				case code == shell.CommandMarker && ms.config.Delineation == DelineationHooks:
+					ms.commandStarted(params)
+					return false
+				case code == shell.LineMarker && ms.config.Delineation == DelineationHooks:
+					select {
+					case ms.lines <- shell.UnescapeLine(params):
+					default:
+					}
+					return false
+				case code == "133" && ms.config.Delineation == DelineationOSC133:
+					ms.semanticPrompt(params)
+				}
+				return true
+			},
+			onCSI: func(params string, final byte) bool {
+				if final != 'h' && final != 'l' {
+					return true
+				}
+				switch params {
+				case "?1049", "?1047", "?47":
+					ms.Lock()
+					ms.altScreen = final == 'h'
+					ms.Unlock()
+				case "?2004":
+					ms.Lock()
+					ms.innerPaste = final == 'h'
+					ms.Unlock()
+					return false
+				}
+				return true
+			},
+		}, activityReader{r: ptmx, at: &ms.outputAt})
+	}()
 
-	if _, err := fmt.Fprintf(ptmx, ". <(%s install)\n", os.Args[0]); err != nil {
-		log.Error("error creating installation command", err)
-		return err
+	if ms.config.Delineation == DelineationHooks {
+		if _, err := io.WriteString(ptmx, ms.dialect.Bootstrap(os.Args[0])); err != nil {
+			log.Error("error creating installation command", err)
+			return err
+		}
 	}
 
 	return <-ms.doneChan
 }
 
 func (ms *MetaShell) start(ctx context.Context) {
-	ms.scanner = bufio.NewScanner(ms.in)
+	var (
+		decoder = inputDecoder{in: ms.in}
+		// held are the keys of a partially typed leader chord
+		held []token
+	)
 
-	ms.scanner.Split(func(data []byte, atEOF bool) (advance int, token []byte, err error) {
-		switch len(data) {
-		case 0:
+	// feedLeader reports whether tok continues the leader chord,
+	// entering metamode once the chord is complete.
+	feedLeader := func(tok token) bool {
+		if !bytes.Equal(tok.raw, ms.leader[len(held)]) {
+			return false
+		}
+		held = append(held, tok)
+		if len(held) == len(ms.leader) {
+			held = nil
+			ms.metamode()
+		}
+		return true
+	}
+
+	flushHeld := func() {
+		for _, tok := range held {
+			ms.handleInput(tok)
+		}
+		held = nil
+	}
+
+	for {
+		var timeout time.Duration
+		if 0 < len(held) {
+			timeout = ms.config.LeaderTimeout
+		}
+
+		tok, err := decoder.next(timeout)
+		switch {
+		case errors.Is(err, errInputTimeout):
+			flushHeld()
+			continue
+		case err != nil:
+			if ctx.Err() == nil {
+				log.Error("error reading input", err)
+			}
+			return
+		}
+
+		if ms.passthrough() {
+			flushHeld()
+			ms.forward(tok)
+			continue
+		}
+
+		if feedLeader(tok) {
+			continue
+		}
+		if 0 < len(held) {
+			flushHeld()
+			if feedLeader(tok) {
+				continue
+			}
+		}
+
+		ms.handleInput(tok)
+	}
+}
+
+// passthrough reports whether input should go straight to the PTY, as is
+// the case while a command runs, a full-screen application is using the
+// alternate screen or some program other than the shell owns the terminal.
+func (ms *MetaShell) passthrough() bool {
+	ms.RLock()
+	defer ms.RUnlock()
+
+	if ms.cmdIsRunning || ms.altScreen {
+		return true
+	}
+
+	pgrp, err := unix.IoctlGetInt(int(ms.ptmx.Fd()), unix.TIOCGPGRP)
+	if err != nil {
+		log.Error("error getting foreground process group", err)
+		return false
+	}
+
+	return pgrp != ms.cmd.Process.Pid
+}
+
+// handleInput forwards tok to the shell, keeping track of the command line
+// being typed.
+func (ms *MetaShell) handleInput(tok token) {
+	ms.RLock()
+	cmdIsRunning := ms.cmdIsRunning
+	ms.RUnlock()
+
+	if !cmdIsRunning {
+		switch {
+		case tok.kind == tokenControl && tok.raw[0] == 13: // \n
+			ms.Lock()
+			ms.lastLine = ms.cmdBuffer
+			ms.Unlock()
+			ms.cmdBuffer = ""
+			ms.cmdBufferStale = false
+		case tok.kind == tokenControl && (tok.raw[0] == 127 || tok.raw[0] == 8): // backspace
+			_, size := utf8.DecodeLastRuneInString(ms.cmdBuffer)
+			ms.cmdBuffer = ms.cmdBuffer[:len(ms.cmdBuffer)-size]
+		case tok.kind == tokenControl && tok.raw[0] == 3: // ctrl-c
+			ms.cmdBuffer = ""
+			ms.cmdBufferStale = false
+		case tok.kind == tokenControl && tok.raw[0] == 21: // ctrl-u
+			// kills what is before the cursor, which is only
+			// known to be the whole line if the line is known
+			ms.cmdBuffer = ""
+		case tok.kind == tokenPaste:
+			// a paste is part of the line being typed, however many lines it spans
+			text := strings.ReplaceAll(tok.text(), "\r\n", "\n")
+			ms.cmdBuffer += strings.ReplaceAll(text, "\r", "\n")
+		case tok.kind == tokenText:
+			ms.cmdBuffer += tok.text()
 		default:
-			advance = 1
-			token = data
+			// moves the cursor, edits or recalls the line in ways
+			// that only the shell knows the outcome of
+			ms.cmdBufferStale = true
 		}
-		return
-	})
+	}
+
+	ms.forward(tok)
+}
 
-	for ms.scanner.Scan() {
+// forward writes tok to the PTY. Pastes are written in one go, keeping
+// the bracketed paste markers only if the program in the PTY asked for them.
+func (ms *MetaShell) forward(tok token) {
+	if tok.kind == tokenPaste {
 		ms.RLock()
-		cmdIsRunning := ms.cmdIsRunning
+		innerPaste := ms.innerPaste
 		ms.RUnlock()
 
-		input := ms.scanner.Bytes()
-		if !cmdIsRunning {
-			switch input[0] {
-			case 27: // ESC
-				var mh metamode.Handler
-				p := tea.NewProgram(&mh, tea.WithAltScreen())
-				if err := mh.Initialize(ms.client, p.Quit); err != nil {
-					panic(err)
-				}
-				if err := p.Start(); err != nil {
-					panic(err)
-				}
-				if out := mh.GetShellInjection(); out != "" {
-					ms.out.Write([]byte(out))
-				}
-			case 13: // \n
-				log.Debug("registering")
-				_, err := ms.client.RegisterCommandEntry(ctx, &daemonproto.CommandEntry{
-					Command:   ms.cmdBuffer,
-					Tty:       ms.tty,
-					Timestamp: time.Now().Unix(),
-				})
-				if err != nil {
-					log.Error("error registering command with daemon", err)
-				}
+		if !innerPaste {
+			io.WriteString(ms.out, tok.text())
+			return
+		}
+	}
 
-				ms.cmdBuffer = ""
-				ms.Lock()
-				ms.cmdIsRunning = true
-				ms.Unlock()
+	ms.out.Write(tok.raw)
+}
 
-				ms.out.Write([]byte{13})
-			default:
-				ms.cmdBuffer += string(input)
-				ms.out.Write(input)
-			}
+func (ms *MetaShell) metamode() {
+	var (
+		mh     metamode.Handler
+		cfg    = ms.config.Metamode
+		opts   []tea.ProgramOption
+		inline = cfg.Inline()
+		// asked for before drawing anything, the shell
+		// redrawing the line as it reports it
+		line = metamode.CommandLine{
+			Text: ms.commandLine(),
+			// only the hooks bind the key clearing the line
+			Replaceable: ms.config.Delineation == DelineationHooks,
+		}
+	)
+	if inline {
+		rows, _, err := pty.Getsize(os.Stdin)
+		if err != nil {
+			log.Error("error getting terminal size", err)
+			inline = false
+		} else {
+			// make room for the popup below the prompt, scrolling if needed,
+			// and remember where the cursor was to go back there afterwards
+			h := cfg.PopupHeight(rows)
+			fmt.Fprintf(os.Stdout, "%s\x1b[%dA\x1b7\n\r", strings.Repeat("\n", h), h)
+		}
+	}
+	if !inline {
+		opts = append(opts, tea.WithAltScreen())
+	}
+
+	p := tea.NewProgram(&mh, opts...)
+	if err := mh.Initialize(cfg, ms.client, line, p.Quit); err != nil {
+		log.Error("error initializing metamode", err)
+		if inline {
+			io.WriteString(os.Stdout, "\x1b8")
+		}
+		return
+	}
+	// bubbletea does not understand bracketed pastes
+	io.WriteString(os.Stdout, bracketedPasteOff)
+	defer io.WriteString(os.Stdout, bracketedPasteOn)
+
+	err := p.Start()
+	if inline {
+		io.WriteString(os.Stdout, "\x1b8")
+	}
+	if err != nil {
+		log.Error("error running metamode", err)
+		return
+	}
+	out := mh.GetShellInjection()
+	if mh.ReplacesCommandLine() {
+		ms.out.Write(ms.dialect.ClearLine())
+		ms.cmdBuffer = ""
+	}
+	if out != "" {
+		ms.out.Write([]byte(out))
+		ms.cmdBuffer += out
+	}
+}
+
+// commandLine returns the command line being typed. The hooks are asked
+// for it, falling back on the line tracked from the keys typed if they do not
+// answer in time; the line is empty if it is not known.
+func (ms *MetaShell) commandLine() string {
+	if ms.config.Delineation == DelineationHooks {
+		select {
+		case <-ms.lines: // a late answer to a previous request
+		default:
+		}
+		if _, err := ms.out.Write(ms.dialect.ReportLine()); err != nil {
+			log.Error("error asking the shell for the command line", err)
 		} else {
-			ms.out.Write(input)
+			select {
+			case line := <-ms.lines:
+				ms.cmdBuffer = line
+				ms.cmdBufferStale = false
+				ms.awaitRedraw()
+				return line
+			case <-time.After(lineTimeout):
+				log.Warn("the shell did not report the command line in time")
+			}
+		}
+	}
+
+	if ms.cmdBufferStale {
+		return ""
+	}
+	return ms.cmdBuffer
+}
+
+// awaitRedraw waits for the shell to be done redrawing the line,
+// giving up after lineTimeout.
+func (ms *MetaShell) awaitRedraw() {
+	deadline := time.Now().Add(lineTimeout)
+	for time.Now().Before(deadline) {
+		quiet := time.Since(time.Unix(0, ms.outputAt.Load()))
+		if redrawPause <= quiet {
+			return
+		}
+		time.Sleep(redrawPause - quiet)
+	}
+}
+
+// activityReader records when data was last read from r.
+type activityReader struct {
+	r  io.Reader
+	at *atomic.Int64
+}
+
+func (a activityReader) Read(p []byte) (int, error) {
+	n, err := a.r.Read(p)
+	if 0 < n {
+		a.at.Store(time.Now().UnixNano())
+	}
+	return n, err
+}
+
+// commandStarted is called when the shell hooks announce that the command
+// with the given key is about to run. The key is shared with the hooks,
+// which report the command to the daemon under the same key.
+func (ms *MetaShell) commandStarted(key string) {
+	ms.Lock()
+	line := ms.lastLine
+	ms.lastLine = ""
+	ms.cmdIsRunning = true
+	ms.Unlock()
+
+	entry := &daemonproto.CommandEntry{
+		Command:   line,
+		Tty:       ms.tty,
+		Timestamp: time.Now().Unix(),
+		Key:       key,
+	}
+	ms.report(func(ctx context.Context) {
+		log.Debug("registering", "key", key)
+		if _, err := ms.client.RegisterCommandEntry(ctx, entry); err != nil {
+			log.Error("error registering command with daemon", err)
 		}
+	})
+}
+
+// report queues a report of the command lifecycle to the daemon. Reports are
+// sent in order from their own goroutine, each within reportTimeout, so that
+// a slow daemon never holds up the output of the shell.
+func (ms *MetaShell) report(send func(context.Context)) {
+	select {
+	case ms.reports <- send:
+	default:
+		log.Warn("dropping command report, the daemon is not keeping up")
+	}
+}
+
+func (ms *MetaShell) sendReports(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case send := <-ms.reports:
+			sendCtx, cancel := context.WithTimeout(ctx, reportTimeout)
+			send(sendCtx)
+			cancel()
+		}
+	}
+}
+
+func newSessionNonce() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
+	return hex.EncodeToString(b), nil
 }
 
 func setTTYSettings(fd int) (*unix.Termios, error) {
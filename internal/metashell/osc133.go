@@ -0,0 +1,98 @@
+package metashell
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raphaelreyna/metashell/internal/log"
+	daemonproto "github.com/raphaelreyna/metashell/internal/rpc/go/daemon"
+)
+
+// semanticPrompt handles the FinalTerm (OSC 133) semantic prompt markers:
+//
+//	A        prompt start
+//	B        command input start
+//	C        command output start; the command is about to run
+//	D[;exit] command finished with the given exit status
+//
+// The command line may be attached to the C marker as a cmdline or
+// cmdline_url (percent-encoded) parameter, as done by kitty's shell
+// integration; otherwise only the typed keystrokes are reported.
+//
+// In this delineation mode metashell reports the command lifecycle to the
+// daemon itself, in place of the shell hooks.
+func (ms *MetaShell) semanticPrompt(params string) {
+	marker, args, _ := strings.Cut(params, ";")
+
+	switch marker {
+	case "C":
+		ms.Lock()
+		ms.seq++
+		key := fmt.Sprintf("%s-%d", ms.session, ms.seq)
+		ms.pendingKey = key
+		ms.Unlock()
+
+		ms.commandStarted(key)
+
+		query := &daemonproto.PreRunQueryRequest{
+			Command:   commandLineParam(args),
+			Tty:       ms.tty,
+			Timestamp: time.Now().Unix(),
+			Key:       key,
+		}
+		ms.report(func(ctx context.Context) {
+			if _, err := ms.shellclient.PreRunQuery(ctx, query); err != nil {
+				log.Error("error reporting command start to daemon", err)
+			}
+		})
+	case "D":
+		ms.Lock()
+		key := ms.pendingKey
+		ms.pendingKey = ""
+		ms.Unlock()
+
+		if key == "" {
+			// the prompt was abandoned without running a command
+			return
+		}
+
+		exitCode := 0
+		if code, _, _ := strings.Cut(args, ";"); code != "" {
+			var err error
+			if exitCode, err = strconv.Atoi(code); err != nil {
+				log.Warn("invalid exit status in OSC 133 marker",
+					"marker", params,
+				)
+			}
+		}
+
+		runReport := &daemonproto.PostRunReportRequest{
+			Uuid:     key,
+			ExitCode: int32(exitCode),
+		}
+		ms.report(func(ctx context.Context) {
+			if _, err := ms.shellclient.PostRunReport(ctx, runReport); err != nil {
+				log.Error("error reporting command exit code to daemon", err)
+			}
+		})
+	}
+}
+
+func commandLineParam(args string) string {
+	for _, arg := range strings.Split(args, ";") {
+		k, v, _ := strings.Cut(arg, "=")
+		switch k {
+		case "cmdline":
+			return v
+		case "cmdline_url":
+			if cmd, err := url.PathUnescape(v); err == nil {
+				return cmd
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+package metashell
+
+import (
+	"io"
+)
+
+const maxSequenceLen = 4096
+
+type outputState int
+
+const (
+	outputGround outputState = iota
+	outputEsc
+	outputOSC
+	outputOSCEsc
+	outputCSI
+)
+
+// outputFilter copies the output of the shell to the terminal while
+// looking out for the OSC and CSI sequences metashell is interested in.
+// Sequences split across writes are held back until they are complete.
+type outputFilter struct {
+	out io.Writer
+
+	// onOSC is called with the payload of every OSC sequence;
+	// the sequence is forwarded to the terminal if it returns true.
+	onOSC func(payload string) bool
+	// onCSI is called with the parameters and final byte of every
+	// control sequence; the sequence is forwarded if it returns true.
+	onCSI func(params string, final byte) bool
+
+	state outputState
+	seq   []byte
+	buf   []byte
+}
+
+func (f *outputFilter) Write(p []byte) (int, error) {
+	f.buf = f.buf[:0]
+
+	for _, b := range p {
+		switch f.state {
+		case outputGround:
+			if b == 0x1b {
+				f.state = outputEsc
+				f.seq = append(f.seq[:0], b)
+				continue
+			}
+			f.buf = append(f.buf, b)
+		case outputEsc:
+			switch b {
+			case ']':
+				f.seq = append(f.seq, b)
+				f.state = outputOSC
+			case '[':
+				f.seq = append(f.seq, b)
+				f.state = outputCSI
+			case 0x1b:
+				f.buf = append(f.buf, f.seq...)
+				f.seq = append(f.seq[:0], b)
+			default:
+				f.seq = append(f.seq, b)
+				f.flushSeq()
+			}
+		case outputOSC:
+			f.seq = append(f.seq, b)
+			switch b {
+			case 0x07: // BEL
+				f.endOSC(f.seq[2 : len(f.seq)-1])
+			case 0x1b:
+				f.state = outputOSCEsc
+			default:
+				if maxSequenceLen < len(f.seq) {
+					f.flushSeq()
+				}
+			}
+		case outputCSI:
+			f.seq = append(f.seq, b)
+			switch {
+			case 0x40 <= b && b <= 0x7e:
+				if f.onCSI == nil || f.onCSI(string(f.seq[2:len(f.seq)-1]), b) {
+					f.flushSeq()
+				} else {
+					f.dropSeq()
+				}
+			case b < 0x20 || maxSequenceLen < len(f.seq):
+				f.flushSeq()
+			}
+		case outputOSCEsc:
+			f.seq = append(f.seq, b)
+			if b == '\\' { // ST
+				f.endOSC(f.seq[2 : len(f.seq)-2])
+			} else {
+				f.state = outputOSC
+			}
+		}
+	}
+
+	if len(f.buf) != 0 {
+		if _, err := f.out.Write(f.buf); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+func (f *outputFilter) endOSC(payload []byte) {
+	if f.onOSC == nil || f.onOSC(string(payload)) {
+		f.flushSeq()
+		return
+	}
+
+	f.dropSeq()
+}
+
+func (f *outputFilter) dropSeq() {
+	f.seq = f.seq[:0]
+	f.state = outputGround
+}
+
+func (f *outputFilter) flushSeq() {
+	f.buf = append(f.buf, f.seq...)
+	f.seq = f.seq[:0]
+	f.state = outputGround
+}
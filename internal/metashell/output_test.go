@@ -0,0 +1,133 @@
+package metashell
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOutputFilter(t *testing.T) {
+	long := "\x1b]0;" + strings.Repeat("x", maxSequenceLen) + "\x07"
+
+	tests := []struct {
+		name string
+		// writes are made to the filter one at a time.
+		writes []string
+		want   string
+		osc    []string
+		csi    []string
+	}{
+		{
+			name:   "plain text",
+			writes: []string{"hello ", "world"},
+			want:   "hello world",
+		},
+		{
+			name:   "osc with bel dropped",
+			writes: []string{"a\x1b]6973;key\x07b"},
+			want:   "ab",
+			osc:    []string{"6973;key"},
+		},
+		{
+			name:   "osc with st dropped",
+			writes: []string{"a\x1b]6973;key\x1b\\b"},
+			want:   "ab",
+			osc:    []string{"6973;key"},
+		},
+		{
+			name:   "osc with bel split",
+			writes: []string{"a\x1b", "]69", "73;k", "ey\x07", "b"},
+			want:   "ab",
+			osc:    []string{"6973;key"},
+		},
+		{
+			name:   "osc with st split",
+			writes: []string{"a\x1b]6973;key\x1b", "\\b"},
+			want:   "ab",
+			osc:    []string{"6973;key"},
+		},
+		{
+			name:   "osc forwarded",
+			writes: []string{"\x1b]0;ti", "tle\x1b\\", "\x1b]133;A\x07$ "},
+			want:   "\x1b]0;title\x1b\\\x1b]133;A\x07$ ",
+			osc:    []string{"0;title", "133;A"},
+		},
+		{
+			name:   "esc inside an osc",
+			writes: []string{"\x1b]0;a\x1bb\x07"},
+			want:   "\x1b]0;a\x1bb\x07",
+			osc:    []string{"0;a\x1bb"},
+		},
+		{
+			name:   "oversized osc",
+			writes: []string{long},
+			want:   long,
+		},
+		{
+			name:   "csi dropped",
+			writes: []string{"\x1b[?20", "04hx"},
+			want:   "x",
+			csi:    []string{"?2004h"},
+		},
+		{
+			name:   "csi forwarded",
+			writes: []string{"\x1b[3", "1mred\x1b", "[0m"},
+			want:   "\x1b[31mred\x1b[0m",
+			csi:    []string{"31m", "0m"},
+		},
+		{
+			name:   "csi cut short by a control",
+			writes: []string{"\x1b[1\n"},
+			want:   "\x1b[1\n",
+		},
+		{
+			name:   "esc esc",
+			writes: []string{"\x1b", "\x1b[A"},
+			want:   "\x1b\x1b[A",
+			csi:    []string{"A"},
+		},
+		{
+			name:   "other escapes",
+			writes: []string{"\x1b7", "\x1b", "8"},
+			want:   "\x1b7\x1b8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				out      bytes.Buffer
+				osc, csi []string
+			)
+			f := outputFilter{
+				out: &out,
+				onOSC: func(payload string) bool {
+					osc = append(osc, payload)
+					return !strings.HasPrefix(payload, "6973;")
+				},
+				onCSI: func(params string, final byte) bool {
+					csi = append(csi, params+string(final))
+					return params != "?2004"
+				},
+			}
+
+			for _, w := range tt.writes {
+				n, err := f.Write([]byte(w))
+				if n != len(w) || err != nil {
+					t.Fatalf("Write(%q) = %d, %v", w, n, err)
+				}
+			}
+
+			if got := out.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(osc, tt.osc) {
+				t.Errorf("OSC payloads = %q, want %q", osc, tt.osc)
+			}
+			if !reflect.DeepEqual(csi, tt.csi) {
+				t.Errorf("CSI sequences = %q, want %q", csi, tt.csi)
+			}
+		})
+	}
+}
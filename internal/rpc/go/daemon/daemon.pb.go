@@ -20,15 +20,78 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type MetacommandArgumentType int32
+
+const (
+	MetacommandArgumentType_STRING MetacommandArgumentType = 0
+	MetacommandArgumentType_INT    MetacommandArgumentType = 1
+	MetacommandArgumentType_FLOAT  MetacommandArgumentType = 2
+	MetacommandArgumentType_BOOL   MetacommandArgumentType = 3
+	MetacommandArgumentType_ENUM   MetacommandArgumentType = 4 // one of enum_values
+)
+
+// Enum value maps for MetacommandArgumentType.
+var (
+	MetacommandArgumentType_name = map[int32]string{
+		0: "STRING",
+		1: "INT",
+		2: "FLOAT",
+		3: "BOOL",
+		4: "ENUM",
+	}
+	MetacommandArgumentType_value = map[string]int32{
+		"STRING": 0,
+		"INT":    1,
+		"FLOAT":  2,
+		"BOOL":   3,
+		"ENUM":   4,
+	}
+)
+
+func (x MetacommandArgumentType) Enum() *MetacommandArgumentType {
+	p := new(MetacommandArgumentType)
+	*p = x
+	return p
+}
+
+func (x MetacommandArgumentType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MetacommandArgumentType) Descriptor() protoreflect.EnumDescriptor {
+	return file_daemon_daemon_proto_enumTypes[0].Descriptor()
+}
+
+func (MetacommandArgumentType) Type() protoreflect.EnumType {
+	return &file_daemon_daemon_proto_enumTypes[0]
+}
+
+func (x MetacommandArgumentType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MetacommandArgumentType.Descriptor instead.
+func (MetacommandArgumentType) EnumDescriptor() ([]byte, []int) {
+	return file_daemon_daemon_proto_rawDescGZIP(), []int{0}
+}
+
 type MetacommandResponseFormat int32
 
 const (
 	MetacommandResponseFormat_UNSPECIFIED          MetacommandResponseFormat = 0
 	MetacommandResponseFormat_TEXT                 MetacommandResponseFormat = 1 // string
-	MetacommandResponseFormat_ITEM_LIST            MetacommandResponseFormat = 2 // [{"title": string, "description": string, "filter_value": string}]
+	MetacommandResponseFormat_ITEM_LIST            MetacommandResponseFormat = 2 // [{"title": string, "description": string, "filter_value": string, "preview": string}]
 	MetacommandResponseFormat_SCREEN               MetacommandResponseFormat = 3 // string
 	MetacommandResponseFormat_SHELL_INJECTION      MetacommandResponseFormat = 4 // string
-	MetacommandResponseFormat_SHELL_INJECTION_LIST MetacommandResponseFormat = 5 // [{"title": string, "description": string, "filter_value": string, "shell_injection": ""}]
+	MetacommandResponseFormat_SHELL_INJECTION_LIST MetacommandResponseFormat = 5 // [{"title": string, "description": string, "filter_value": string, "shell_injection": "", "preview": string}]
+	// {"title": string, "fields": [{"name": string, "label": string, "description": string,
+	//   "type": "string"|"int"|"float"|"bool"|"enum", "options": [string], "default": string,
+	//   "required": bool, "pattern": string}], "submit_format": string}
+	// The metacommand is called again with the same args and the filled in
+	// form_values, its response having the format named by submit_format
+	// (SHELL_INJECTION if unset).
+	MetacommandResponseFormat_FORM          MetacommandResponseFormat = 6
+	MetacommandResponseFormat_SHELL_REPLACE MetacommandResponseFormat = 7 // string, replacing the command line being typed
 )
 
 // Enum value maps for MetacommandResponseFormat.
@@ -40,6 +103,8 @@ var (
 		3: "SCREEN",
 		4: "SHELL_INJECTION",
 		5: "SHELL_INJECTION_LIST",
+		6: "FORM",
+		7: "SHELL_REPLACE",
 	}
 	MetacommandResponseFormat_value = map[string]int32{
 		"UNSPECIFIED":          0,
@@ -48,6 +113,8 @@ var (
 		"SCREEN":               3,
 		"SHELL_INJECTION":      4,
 		"SHELL_INJECTION_LIST": 5,
+		"FORM":                 6,
+		"SHELL_REPLACE":        7,
 	}
 )
 
@@ -62,11 +129,11 @@ func (x MetacommandResponseFormat) String() string {
 }
 
 func (MetacommandResponseFormat) Descriptor() protoreflect.EnumDescriptor {
-	return file_daemon_daemon_proto_enumTypes[0].Descriptor()
+	return file_daemon_daemon_proto_enumTypes[1].Descriptor()
 }
 
 func (MetacommandResponseFormat) Type() protoreflect.EnumType {
-	return &file_daemon_daemon_proto_enumTypes[0]
+	return &file_daemon_daemon_proto_enumTypes[1]
 }
 
 func (x MetacommandResponseFormat) Number() protoreflect.EnumNumber {
@@ -75,7 +142,7 @@ func (x MetacommandResponseFormat) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use MetacommandResponseFormat.Descriptor instead.
 func (MetacommandResponseFormat) EnumDescriptor() ([]byte, []int) {
-	return file_daemon_daemon_proto_rawDescGZIP(), []int{0}
+	return file_daemon_daemon_proto_rawDescGZIP(), []int{1}
 }
 
 type Empty struct {
@@ -171,6 +238,7 @@ type PreRunQueryRequest struct {
 	Command   string `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
 	Tty       string `protobuf:"bytes,2,opt,name=tty,proto3" json:"tty,omitempty"`
 	Timestamp int64  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Key       string `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
 }
 
 func (x *PreRunQueryRequest) Reset() {
@@ -226,6 +294,13 @@ func (x *PreRunQueryRequest) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *PreRunQueryRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
 type PreRunQueryResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -336,6 +411,7 @@ type CommandEntry struct {
 	Command   string `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
 	Tty       string `protobuf:"bytes,2,opt,name=tty,proto3" json:"tty,omitempty"`
 	Timestamp int64  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Key       string `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
 }
 
 func (x *CommandEntry) Reset() {
@@ -391,6 +467,13 @@ func (x *CommandEntry) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *CommandEntry) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
 type CommandKey struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -679,8 +762,19 @@ type MetacommandInfo struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name   string                    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Format MetacommandResponseFormat `protobuf:"varint,3,opt,name=format,proto3,enum=metashell.daemon.MetacommandResponseFormat" json:"format,omitempty"`
+	Name        string                    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Format      MetacommandResponseFormat `protobuf:"varint,3,opt,name=format,proto3,enum=metashell.daemon.MetacommandResponseFormat" json:"format,omitempty"`
+	Args        []*MetacommandArgument    `protobuf:"bytes,4,rep,name=args,proto3" json:"args,omitempty"`
+	Description string                    `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// multi_select lets several items of a SHELL_INJECTION_LIST be picked,
+	// their values being joined with separator (a space if unset).
+	MultiSelect bool   `protobuf:"varint,6,opt,name=multi_select,json=multiSelect,proto3" json:"multi_select,omitempty"`
+	Separator   string `protobuf:"bytes,7,opt,name=separator,proto3" json:"separator,omitempty"`
+	// preview_metacommand names a metacommand of the same plugin previewing
+	// the selected item of an ITEM_LIST or SHELL_INJECTION_LIST. It is called
+	// with the item's value (or title) as its only argument and format args
+	// holding size=WxH, the size of the preview pane.
+	PreviewMetacommand string `protobuf:"bytes,8,opt,name=preview_metacommand,json=previewMetacommand,proto3" json:"preview_metacommand,omitempty"`
 }
 
 func (x *MetacommandInfo) Reset() {
@@ -729,6 +823,130 @@ func (x *MetacommandInfo) GetFormat() MetacommandResponseFormat {
 	return MetacommandResponseFormat_UNSPECIFIED
 }
 
+func (x *MetacommandInfo) GetArgs() []*MetacommandArgument {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *MetacommandInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *MetacommandInfo) GetMultiSelect() bool {
+	if x != nil {
+		return x.MultiSelect
+	}
+	return false
+}
+
+func (x *MetacommandInfo) GetSeparator() string {
+	if x != nil {
+		return x.Separator
+	}
+	return ""
+}
+
+func (x *MetacommandInfo) GetPreviewMetacommand() string {
+	if x != nil {
+		return x.PreviewMetacommand
+	}
+	return ""
+}
+
+// MetacommandArgument describes a positional argument of a metacommand.
+type MetacommandArgument struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type        MetacommandArgumentType `protobuf:"varint,2,opt,name=type,proto3,enum=metashell.daemon.MetacommandArgumentType" json:"type,omitempty"`
+	Required    bool                    `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+	EnumValues  []string                `protobuf:"bytes,4,rep,name=enum_values,json=enumValues,proto3" json:"enum_values,omitempty"`
+	Description string                  `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// dynamic arguments have their values completed by the plugin, see MetacommandRequest.complete_arg
+	Dynamic bool `protobuf:"varint,6,opt,name=dynamic,proto3" json:"dynamic,omitempty"`
+}
+
+func (x *MetacommandArgument) Reset() {
+	*x = MetacommandArgument{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_daemon_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MetacommandArgument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetacommandArgument) ProtoMessage() {}
+
+func (x *MetacommandArgument) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_daemon_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetacommandArgument.ProtoReflect.Descriptor instead.
+func (*MetacommandArgument) Descriptor() ([]byte, []int) {
+	return file_daemon_daemon_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MetacommandArgument) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MetacommandArgument) GetType() MetacommandArgumentType {
+	if x != nil {
+		return x.Type
+	}
+	return MetacommandArgumentType_STRING
+}
+
+func (x *MetacommandArgument) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *MetacommandArgument) GetEnumValues() []string {
+	if x != nil {
+		return x.EnumValues
+	}
+	return nil
+}
+
+func (x *MetacommandArgument) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *MetacommandArgument) GetDynamic() bool {
+	if x != nil {
+		return x.Dynamic
+	}
+	return false
+}
+
 type MetacommandRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -739,12 +957,21 @@ type MetacommandRequest struct {
 	Args        []string `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
 	FormatArgs  []string `protobuf:"bytes,4,rep,name=format_args,json=formatArgs,proto3" json:"format_args,omitempty"`
 	Tty         string   `protobuf:"bytes,5,opt,name=tty,proto3" json:"tty,omitempty"`
+	// complete_arg names the argument whose values are being completed;
+	// the response data is then a JSON list of strings.
+	CompleteArg string `protobuf:"bytes,6,opt,name=complete_arg,json=completeArg,proto3" json:"complete_arg,omitempty"`
+	// form_values holds the values of the fields of the form returned
+	// by the metacommand, once the user has filled it in and submitted it.
+	FormValues map[string]string `protobuf:"bytes,7,rep,name=form_values,json=formValues,proto3" json:"form_values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// command_line is the command line that was being typed in
+	// the shell when metamode was entered.
+	CommandLine string `protobuf:"bytes,8,opt,name=command_line,json=commandLine,proto3" json:"command_line,omitempty"`
 }
 
 func (x *MetacommandRequest) Reset() {
 	*x = MetacommandRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_daemon_daemon_proto_msgTypes[12]
+		mi := &file_daemon_daemon_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -757,7 +984,7 @@ func (x *MetacommandRequest) String() string {
 func (*MetacommandRequest) ProtoMessage() {}
 
 func (x *MetacommandRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_daemon_proto_msgTypes[12]
+	mi := &file_daemon_daemon_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -770,7 +997,7 @@ func (x *MetacommandRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetacommandRequest.ProtoReflect.Descriptor instead.
 func (*MetacommandRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_daemon_proto_rawDescGZIP(), []int{12}
+	return file_daemon_daemon_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *MetacommandRequest) GetPluginName() string {
@@ -808,6 +1035,27 @@ func (x *MetacommandRequest) GetTty() string {
 	return ""
 }
 
+func (x *MetacommandRequest) GetCompleteArg() string {
+	if x != nil {
+		return x.CompleteArg
+	}
+	return ""
+}
+
+func (x *MetacommandRequest) GetFormValues() map[string]string {
+	if x != nil {
+		return x.FormValues
+	}
+	return nil
+}
+
+func (x *MetacommandRequest) GetCommandLine() string {
+	if x != nil {
+		return x.CommandLine
+	}
+	return ""
+}
+
 type MetacommandResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -820,7 +1068,7 @@ type MetacommandResponse struct {
 func (x *MetacommandResponse) Reset() {
 	*x = MetacommandResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_daemon_daemon_proto_msgTypes[13]
+		mi := &file_daemon_daemon_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -833,7 +1081,7 @@ func (x *MetacommandResponse) String() string {
 func (*MetacommandResponse) ProtoMessage() {}
 
 func (x *MetacommandResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_daemon_proto_msgTypes[13]
+	mi := &file_daemon_daemon_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -846,7 +1094,7 @@ func (x *MetacommandResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetacommandResponse.ProtoReflect.Descriptor instead.
 func (*MetacommandResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_daemon_proto_rawDescGZIP(), []int{13}
+	return file_daemon_daemon_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *MetacommandResponse) GetData() []byte {
@@ -863,6 +1111,121 @@ func (x *MetacommandResponse) GetError() string {
 	return ""
 }
 
+// MetacommandPipelineRequest runs metacommands in turn, the data
+// returned by each one being appended to the arguments of the next.
+type MetacommandPipelineRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stages []*MetacommandRequest `protobuf:"bytes,1,rep,name=stages,proto3" json:"stages,omitempty"`
+}
+
+func (x *MetacommandPipelineRequest) Reset() {
+	*x = MetacommandPipelineRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_daemon_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MetacommandPipelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetacommandPipelineRequest) ProtoMessage() {}
+
+func (x *MetacommandPipelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_daemon_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetacommandPipelineRequest.ProtoReflect.Descriptor instead.
+func (*MetacommandPipelineRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_daemon_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MetacommandPipelineRequest) GetStages() []*MetacommandRequest {
+	if x != nil {
+		return x.Stages
+	}
+	return nil
+}
+
+type MetacommandPipelineResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// stage is the index of the stage that responded: the last one, or one
+	// returning a list for the user to pick the input of the next stage from.
+	Stage int32 `protobuf:"varint,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	// request is the request made for that stage, piped input included.
+	Request  *MetacommandRequest  `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	Response *MetacommandResponse `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+}
+
+func (x *MetacommandPipelineResponse) Reset() {
+	*x = MetacommandPipelineResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daemon_daemon_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MetacommandPipelineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetacommandPipelineResponse) ProtoMessage() {}
+
+func (x *MetacommandPipelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_daemon_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetacommandPipelineResponse.ProtoReflect.Descriptor instead.
+func (*MetacommandPipelineResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_daemon_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MetacommandPipelineResponse) GetStage() int32 {
+	if x != nil {
+		return x.Stage
+	}
+	return 0
+}
+
+func (x *MetacommandPipelineResponse) GetRequest() *MetacommandRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *MetacommandPipelineResponse) GetResponse() *MetacommandResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
 var File_daemon_daemon_proto protoreflect.FileDescriptor
 
 var file_daemon_daemon_proto_rawDesc = []byte{
@@ -872,127 +1235,201 @@ var file_daemon_daemon_proto_rawDesc = []byte{
 	0x22, 0x2f, 0x0a, 0x14, 0x50, 0x72, 0x65, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x6d, 0x64, 0x5f,
 	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6d, 0x64, 0x4b, 0x65,
-	0x79, 0x22, 0x5e, 0x0a, 0x12, 0x50, 0x72, 0x65, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x79, 0x22, 0x70, 0x0a, 0x12, 0x50, 0x72, 0x65, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
 	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
 	0x64, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
 	0x74, 0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
 	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x22, 0x29, 0x0a, 0x13, 0x50, 0x72, 0x65, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x22, 0x47, 0x0a, 0x14,
-	0x50, 0x6f, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74,
+	0x70, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x22, 0x29, 0x0a, 0x13, 0x50, 0x72, 0x65, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x22, 0x47,
+	0x0a, 0x14, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78,
+	0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65,
+	0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x6a, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x74, 0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x22, 0x1e, 0x0a, 0x0a, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x4b, 0x65,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x22, 0x40, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x45, 0x78,
+	0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74,
 	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69,
-	0x74, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x58, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12,
-	0x10, 0x0a, 0x03, 0x74, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x74,
-	0x79, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22,
-	0x1e, 0x0a, 0x0a, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22,
-	0x40, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x45, 0x78, 0x69, 0x74, 0x43, 0x6f,
-	0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64,
-	0x65, 0x22, 0x9c, 0x01, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49,
-	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6c,
-	0x75, 0x67, 0x69, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x6d,
-	0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
-	0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x18, 0x6d, 0x65, 0x74, 0x61, 0x63, 0x6f,
-	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x5f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x5f, 0x6f, 0x6e,
-	0x6c, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x6d, 0x65, 0x74, 0x61, 0x63, 0x6f,
-	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x4f, 0x6e, 0x6c, 0x79,
-	0x22, 0x4f, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x6e, 0x66,
-	0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x07, 0x70, 0x6c, 0x75,
-	0x67, 0x69, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6d, 0x65, 0x74,
-	0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x6c,
-	0x75, 0x67, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
-	0x73, 0x22, 0xb9, 0x01, 0x0a, 0x0a, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x36,
-	0x0a, 0x17, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x73, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
-	0x64, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x15, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x73, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52,
-	0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x45, 0x0a, 0x0c, 0x6d, 0x65, 0x74, 0x61, 0x63, 0x6f,
-	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6d,
+	0x74, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x9c, 0x01, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f,
+	0x0a, 0x0b, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x29, 0x0a, 0x10, 0x6d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6d, 0x65, 0x74, 0x61, 0x63,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x18, 0x6d, 0x65,
+	0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x5f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x73, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x6d, 0x65,
+	0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73,
+	0x4f, 0x6e, 0x6c, 0x79, 0x22, 0x4f, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a,
+	0x07, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x73, 0x22, 0xb9, 0x01, 0x0a, 0x0a, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x17, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x73, 0x5f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x15, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x73, 0x43, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x45, 0x0a, 0x0c, 0x6d, 0x65,
+	0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x0c, 0x6d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x73, 0x22, 0xb9, 0x02, 0x0a, 0x0f, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x43, 0x0a, 0x06, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x6d, 0x65, 0x74, 0x61,
+	0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74,
+	0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x39,
+	0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6d,
 	0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e,
-	0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52,
-	0x0c, 0x6d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x22, 0x6a, 0x0a,
-	0x0f, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x49, 0x6e, 0x66, 0x6f,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x43, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c,
+	0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x41, 0x72, 0x67, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6d,
+	0x75, 0x6c, 0x74, 0x69, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0b, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x2f, 0x0a, 0x13,
+	0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x70, 0x72, 0x65, 0x76, 0x69,
+	0x65, 0x77, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x22, 0xe1, 0x01,
+	0x0a, 0x13, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x41, 0x72, 0x67,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3d, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68,
+	0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x63,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x41, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x75, 0x6d, 0x5f, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x6e, 0x75, 0x6d, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x79, 0x6e, 0x61, 0x6d,
+	0x69, 0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x79, 0x6e, 0x61, 0x6d, 0x69,
+	0x63, 0x22, 0xfb, 0x02, 0x0a, 0x12, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65, 0x74,
+	0x61, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x6d, 0x65, 0x74, 0x61, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x61, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x5f, 0x61, 0x72, 0x67, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x41, 0x72, 0x67,
+	0x73, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x74, 0x74, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x5f,
+	0x61, 0x72, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x6c,
+	0x65, 0x74, 0x65, 0x41, 0x72, 0x67, 0x12, 0x55, 0x0a, 0x0b, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6d, 0x65,
+	0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d,
+	0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x46, 0x6f, 0x72, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0a, 0x66, 0x6f, 0x72, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x4c, 0x69, 0x6e, 0x65,
+	0x1a, 0x3d, 0x0a, 0x0f, 0x46, 0x6f, 0x72, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x3f, 0x0a, 0x13, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x22, 0x5a, 0x0a, 0x1a, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x50,
+	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24,
+	0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x06, 0x73, 0x74, 0x61, 0x67, 0x65, 0x73, 0x22, 0xb6, 0x01, 0x0a,
+	0x1b, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x50, 0x69, 0x70, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x67, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e,
+	0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x41, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c,
 	0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d,
-	0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61,
-	0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x9f, 0x01, 0x0a, 0x12, 0x4d, 0x65,
-	0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65, 0x74, 0x61, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x65, 0x74, 0x61, 0x43, 0x6f, 0x6d,
-	0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x74, 0x5f, 0x61, 0x72, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x66,
-	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x41, 0x72, 0x67, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x79,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x74, 0x79, 0x22, 0x3f, 0x0a, 0x13, 0x4d,
-	0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x2a, 0x80, 0x01, 0x0a,
-	0x19, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e,
-	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x54,
-	0x45, 0x58, 0x54, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x4c, 0x49,
-	0x53, 0x54, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x43, 0x52, 0x45, 0x45, 0x4e, 0x10, 0x03,
-	0x12, 0x13, 0x0a, 0x0f, 0x53, 0x48, 0x45, 0x4c, 0x4c, 0x5f, 0x49, 0x4e, 0x4a, 0x45, 0x43, 0x54,
-	0x49, 0x4f, 0x4e, 0x10, 0x04, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x48, 0x45, 0x4c, 0x4c, 0x5f, 0x49,
-	0x4e, 0x4a, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x05, 0x32,
-	0xc1, 0x01, 0x0a, 0x11, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x44,
-	0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x12, 0x5a, 0x0a, 0x0b, 0x50, 0x72, 0x65, 0x52, 0x75, 0x6e, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x12, 0x24, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c,
-	0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x65, 0x52, 0x75, 0x6e, 0x51, 0x75,
-	0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6d, 0x65, 0x74,
-	0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x72,
-	0x65, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x50, 0x0a, 0x0d, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x70, 0x6f,
-	0x72, 0x74, 0x12, 0x26, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64,
-	0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x70,
-	0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6d, 0x65, 0x74,
-	0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x32, 0xf8, 0x02, 0x0a, 0x0f, 0x4d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c,
-	0x6c, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x12, 0x51, 0x0a, 0x11, 0x4e, 0x65, 0x77, 0x45, 0x78,
-	0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x17, 0x2e, 0x6d,
-	0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x21, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c,
-	0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
-	0x45, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x30, 0x01, 0x12, 0x54, 0x0a, 0x14, 0x52, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x1e, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64,
-	0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x1a, 0x1c, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64,
-	0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x4b, 0x65, 0x79,
-	0x12, 0x5a, 0x0a, 0x0b, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12,
-	0x24, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d,
-	0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c,
-	0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d,
-	0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x0d,
-	0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x26, 0x2e,
-	0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e,
-	0x2e, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c,
-	0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67,
-	0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2e,
-	0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x61, 0x70,
-	0x68, 0x61, 0x65, 0x6c, 0x72, 0x65, 0x79, 0x6e, 0x61, 0x2f, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68,
-	0x65, 0x6c, 0x6c, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x4d, 0x0a, 0x17, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x41, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03,
+	0x49, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x4c, 0x4f, 0x41, 0x54, 0x10, 0x02,
+	0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x4f, 0x4c, 0x10, 0x03, 0x12, 0x08, 0x0a, 0x04, 0x45, 0x4e,
+	0x55, 0x4d, 0x10, 0x04, 0x2a, 0x9d, 0x01, 0x0a, 0x19, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x46, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x45, 0x58, 0x54, 0x10, 0x01, 0x12, 0x0d, 0x0a,
+	0x09, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06,
+	0x53, 0x43, 0x52, 0x45, 0x45, 0x4e, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x48, 0x45, 0x4c,
+	0x4c, 0x5f, 0x49, 0x4e, 0x4a, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x04, 0x12, 0x18, 0x0a,
+	0x14, 0x53, 0x48, 0x45, 0x4c, 0x4c, 0x5f, 0x49, 0x4e, 0x4a, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x4f, 0x52, 0x4d, 0x10,
+	0x06, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x48, 0x45, 0x4c, 0x4c, 0x5f, 0x52, 0x45, 0x50, 0x4c, 0x41,
+	0x43, 0x45, 0x10, 0x07, 0x32, 0xc1, 0x01, 0x0a, 0x11, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x12, 0x5a, 0x0a, 0x0b, 0x50, 0x72,
+	0x65, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x24, 0x2e, 0x6d, 0x65, 0x74, 0x61,
+	0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x65,
+	0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x25, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x65, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0d, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x75,
+	0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x26, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68,
+	0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52,
+	0x75, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x17, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x32, 0xec, 0x03, 0x0a, 0x0f, 0x4d, 0x65, 0x74,
+	0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x12, 0x51, 0x0a, 0x11,
+	0x4e, 0x65, 0x77, 0x45, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x12, 0x17, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61,
+	0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x21, 0x2e, 0x6d, 0x65, 0x74,
+	0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x43, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x45, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x30, 0x01, 0x12,
+	0x54, 0x0a, 0x14, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1e, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68,
+	0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x1a, 0x1c, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68,
+	0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x5a, 0x0a, 0x0b, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x12, 0x24, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c,
+	0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6d, 0x65, 0x74,
+	0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x65,
+	0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x60, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x26, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64,
+	0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x6d, 0x65, 0x74,
+	0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x47, 0x65,
+	0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x72, 0x0a, 0x13, 0x4d, 0x65, 0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x2c, 0x2e, 0x6d, 0x65, 0x74,
+	0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x65,
+	0x74, 0x61, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x73,
+	0x68, 0x65, 0x6c, 0x6c, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x61,
+	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x61, 0x70, 0x68, 0x61, 0x65, 0x6c, 0x72, 0x65, 0x79,
+	0x6e, 0x61, 0x2f, 0x6d, 0x65, 0x74, 0x61, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x2f, 0x72, 0x70, 0x63,
+	0x2f, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1007,46 +1444,59 @@ func file_daemon_daemon_proto_rawDescGZIP() []byte {
 	return file_daemon_daemon_proto_rawDescData
 }
 
-var file_daemon_daemon_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_daemon_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_daemon_daemon_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_daemon_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_daemon_daemon_proto_goTypes = []interface{}{
-	(MetacommandResponseFormat)(0), // 0: metashell.daemon.MetacommandResponseFormat
-	(*Empty)(nil),                  // 1: metashell.daemon.Empty
-	(*PreRunReportResponse)(nil),   // 2: metashell.daemon.PreRunReportResponse
-	(*PreRunQueryRequest)(nil),     // 3: metashell.daemon.PreRunQueryRequest
-	(*PreRunQueryResponse)(nil),    // 4: metashell.daemon.PreRunQueryResponse
-	(*PostRunReportRequest)(nil),   // 5: metashell.daemon.PostRunReportRequest
-	(*CommandEntry)(nil),           // 6: metashell.daemon.CommandEntry
-	(*CommandKey)(nil),             // 7: metashell.daemon.CommandKey
-	(*CommandExitCode)(nil),        // 8: metashell.daemon.CommandExitCode
-	(*GetPluginInfoRequest)(nil),   // 9: metashell.daemon.GetPluginInfoRequest
-	(*GetPluginInfoResponse)(nil),  // 10: metashell.daemon.GetPluginInfoResponse
-	(*PluginInfo)(nil),             // 11: metashell.daemon.PluginInfo
-	(*MetacommandInfo)(nil),        // 12: metashell.daemon.MetacommandInfo
-	(*MetacommandRequest)(nil),     // 13: metashell.daemon.MetacommandRequest
-	(*MetacommandResponse)(nil),    // 14: metashell.daemon.MetacommandResponse
+	(MetacommandArgumentType)(0),        // 0: metashell.daemon.MetacommandArgumentType
+	(MetacommandResponseFormat)(0),      // 1: metashell.daemon.MetacommandResponseFormat
+	(*Empty)(nil),                       // 2: metashell.daemon.Empty
+	(*PreRunReportResponse)(nil),        // 3: metashell.daemon.PreRunReportResponse
+	(*PreRunQueryRequest)(nil),          // 4: metashell.daemon.PreRunQueryRequest
+	(*PreRunQueryResponse)(nil),         // 5: metashell.daemon.PreRunQueryResponse
+	(*PostRunReportRequest)(nil),        // 6: metashell.daemon.PostRunReportRequest
+	(*CommandEntry)(nil),                // 7: metashell.daemon.CommandEntry
+	(*CommandKey)(nil),                  // 8: metashell.daemon.CommandKey
+	(*CommandExitCode)(nil),             // 9: metashell.daemon.CommandExitCode
+	(*GetPluginInfoRequest)(nil),        // 10: metashell.daemon.GetPluginInfoRequest
+	(*GetPluginInfoResponse)(nil),       // 11: metashell.daemon.GetPluginInfoResponse
+	(*PluginInfo)(nil),                  // 12: metashell.daemon.PluginInfo
+	(*MetacommandInfo)(nil),             // 13: metashell.daemon.MetacommandInfo
+	(*MetacommandArgument)(nil),         // 14: metashell.daemon.MetacommandArgument
+	(*MetacommandRequest)(nil),          // 15: metashell.daemon.MetacommandRequest
+	(*MetacommandResponse)(nil),         // 16: metashell.daemon.MetacommandResponse
+	(*MetacommandPipelineRequest)(nil),  // 17: metashell.daemon.MetacommandPipelineRequest
+	(*MetacommandPipelineResponse)(nil), // 18: metashell.daemon.MetacommandPipelineResponse
+	nil,                                 // 19: metashell.daemon.MetacommandRequest.FormValuesEntry
 }
 var file_daemon_daemon_proto_depIdxs = []int32{
-	11, // 0: metashell.daemon.GetPluginInfoResponse.plugins:type_name -> metashell.daemon.PluginInfo
-	12, // 1: metashell.daemon.PluginInfo.metacommands:type_name -> metashell.daemon.MetacommandInfo
-	0,  // 2: metashell.daemon.MetacommandInfo.format:type_name -> metashell.daemon.MetacommandResponseFormat
-	3,  // 3: metashell.daemon.ShellclientDaemon.PreRunQuery:input_type -> metashell.daemon.PreRunQueryRequest
-	5,  // 4: metashell.daemon.ShellclientDaemon.PostRunReport:input_type -> metashell.daemon.PostRunReportRequest
-	1,  // 5: metashell.daemon.MetashellDaemon.NewExitCodeStream:input_type -> metashell.daemon.Empty
-	6,  // 6: metashell.daemon.MetashellDaemon.RegisterCommandEntry:input_type -> metashell.daemon.CommandEntry
-	13, // 7: metashell.daemon.MetashellDaemon.Metacommand:input_type -> metashell.daemon.MetacommandRequest
-	9,  // 8: metashell.daemon.MetashellDaemon.GetPluginInfo:input_type -> metashell.daemon.GetPluginInfoRequest
-	4,  // 9: metashell.daemon.ShellclientDaemon.PreRunQuery:output_type -> metashell.daemon.PreRunQueryResponse
-	1,  // 10: metashell.daemon.ShellclientDaemon.PostRunReport:output_type -> metashell.daemon.Empty
-	8,  // 11: metashell.daemon.MetashellDaemon.NewExitCodeStream:output_type -> metashell.daemon.CommandExitCode
-	7,  // 12: metashell.daemon.MetashellDaemon.RegisterCommandEntry:output_type -> metashell.daemon.CommandKey
-	14, // 13: metashell.daemon.MetashellDaemon.Metacommand:output_type -> metashell.daemon.MetacommandResponse
-	10, // 14: metashell.daemon.MetashellDaemon.GetPluginInfo:output_type -> metashell.daemon.GetPluginInfoResponse
-	9,  // [9:15] is the sub-list for method output_type
-	3,  // [3:9] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	12, // 0: metashell.daemon.GetPluginInfoResponse.plugins:type_name -> metashell.daemon.PluginInfo
+	13, // 1: metashell.daemon.PluginInfo.metacommands:type_name -> metashell.daemon.MetacommandInfo
+	1,  // 2: metashell.daemon.MetacommandInfo.format:type_name -> metashell.daemon.MetacommandResponseFormat
+	14, // 3: metashell.daemon.MetacommandInfo.args:type_name -> metashell.daemon.MetacommandArgument
+	0,  // 4: metashell.daemon.MetacommandArgument.type:type_name -> metashell.daemon.MetacommandArgumentType
+	19, // 5: metashell.daemon.MetacommandRequest.form_values:type_name -> metashell.daemon.MetacommandRequest.FormValuesEntry
+	15, // 6: metashell.daemon.MetacommandPipelineRequest.stages:type_name -> metashell.daemon.MetacommandRequest
+	15, // 7: metashell.daemon.MetacommandPipelineResponse.request:type_name -> metashell.daemon.MetacommandRequest
+	16, // 8: metashell.daemon.MetacommandPipelineResponse.response:type_name -> metashell.daemon.MetacommandResponse
+	4,  // 9: metashell.daemon.ShellclientDaemon.PreRunQuery:input_type -> metashell.daemon.PreRunQueryRequest
+	6,  // 10: metashell.daemon.ShellclientDaemon.PostRunReport:input_type -> metashell.daemon.PostRunReportRequest
+	2,  // 11: metashell.daemon.MetashellDaemon.NewExitCodeStream:input_type -> metashell.daemon.Empty
+	7,  // 12: metashell.daemon.MetashellDaemon.RegisterCommandEntry:input_type -> metashell.daemon.CommandEntry
+	15, // 13: metashell.daemon.MetashellDaemon.Metacommand:input_type -> metashell.daemon.MetacommandRequest
+	10, // 14: metashell.daemon.MetashellDaemon.GetPluginInfo:input_type -> metashell.daemon.GetPluginInfoRequest
+	17, // 15: metashell.daemon.MetashellDaemon.MetacommandPipeline:input_type -> metashell.daemon.MetacommandPipelineRequest
+	5,  // 16: metashell.daemon.ShellclientDaemon.PreRunQuery:output_type -> metashell.daemon.PreRunQueryResponse
+	2,  // 17: metashell.daemon.ShellclientDaemon.PostRunReport:output_type -> metashell.daemon.Empty
+	9,  // 18: metashell.daemon.MetashellDaemon.NewExitCodeStream:output_type -> metashell.daemon.CommandExitCode
+	8,  // 19: metashell.daemon.MetashellDaemon.RegisterCommandEntry:output_type -> metashell.daemon.CommandKey
+	16, // 20: metashell.daemon.MetashellDaemon.Metacommand:output_type -> metashell.daemon.MetacommandResponse
+	11, // 21: metashell.daemon.MetashellDaemon.GetPluginInfo:output_type -> metashell.daemon.GetPluginInfoResponse
+	18, // 22: metashell.daemon.MetashellDaemon.MetacommandPipeline:output_type -> metashell.daemon.MetacommandPipelineResponse
+	16, // [16:23] is the sub-list for method output_type
+	9,  // [9:16] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_daemon_daemon_proto_init() }
@@ -1200,7 +1650,7 @@ func file_daemon_daemon_proto_init() {
 			}
 		}
 		file_daemon_daemon_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MetacommandRequest); i {
+			switch v := v.(*MetacommandArgument); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1212,6 +1662,18 @@ func file_daemon_daemon_proto_init() {
 			}
 		}
 		file_daemon_daemon_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MetacommandRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_daemon_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MetacommandResponse); i {
 			case 0:
 				return &v.state
@@ -1223,14 +1685,38 @@ func file_daemon_daemon_proto_init() {
 				return nil
 			}
 		}
+		file_daemon_daemon_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MetacommandPipelineRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daemon_daemon_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MetacommandPipelineResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_daemon_daemon_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   14,
+			NumEnums:      2,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
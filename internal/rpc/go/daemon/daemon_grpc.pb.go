@@ -152,6 +152,7 @@ const (
 	MetashellDaemon_RegisterCommandEntry_FullMethodName = "/metashell.daemon.MetashellDaemon/RegisterCommandEntry"
 	MetashellDaemon_Metacommand_FullMethodName          = "/metashell.daemon.MetashellDaemon/Metacommand"
 	MetashellDaemon_GetPluginInfo_FullMethodName        = "/metashell.daemon.MetashellDaemon/GetPluginInfo"
+	MetashellDaemon_MetacommandPipeline_FullMethodName  = "/metashell.daemon.MetashellDaemon/MetacommandPipeline"
 )
 
 // MetashellDaemonClient is the client API for MetashellDaemon service.
@@ -162,6 +163,7 @@ type MetashellDaemonClient interface {
 	RegisterCommandEntry(ctx context.Context, in *CommandEntry, opts ...grpc.CallOption) (*CommandKey, error)
 	Metacommand(ctx context.Context, in *MetacommandRequest, opts ...grpc.CallOption) (*MetacommandResponse, error)
 	GetPluginInfo(ctx context.Context, in *GetPluginInfoRequest, opts ...grpc.CallOption) (*GetPluginInfoResponse, error)
+	MetacommandPipeline(ctx context.Context, in *MetacommandPipelineRequest, opts ...grpc.CallOption) (*MetacommandPipelineResponse, error)
 }
 
 type metashellDaemonClient struct {
@@ -235,6 +237,16 @@ func (c *metashellDaemonClient) GetPluginInfo(ctx context.Context, in *GetPlugin
 	return out, nil
 }
 
+func (c *metashellDaemonClient) MetacommandPipeline(ctx context.Context, in *MetacommandPipelineRequest, opts ...grpc.CallOption) (*MetacommandPipelineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MetacommandPipelineResponse)
+	err := c.cc.Invoke(ctx, MetashellDaemon_MetacommandPipeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MetashellDaemonServer is the server API for MetashellDaemon service.
 // All implementations must embed UnimplementedMetashellDaemonServer
 // for forward compatibility
@@ -243,6 +255,7 @@ type MetashellDaemonServer interface {
 	RegisterCommandEntry(context.Context, *CommandEntry) (*CommandKey, error)
 	Metacommand(context.Context, *MetacommandRequest) (*MetacommandResponse, error)
 	GetPluginInfo(context.Context, *GetPluginInfoRequest) (*GetPluginInfoResponse, error)
+	MetacommandPipeline(context.Context, *MetacommandPipelineRequest) (*MetacommandPipelineResponse, error)
 	mustEmbedUnimplementedMetashellDaemonServer()
 }
 
@@ -262,6 +275,9 @@ func (UnimplementedMetashellDaemonServer) Metacommand(context.Context, *Metacomm
 func (UnimplementedMetashellDaemonServer) GetPluginInfo(context.Context, *GetPluginInfoRequest) (*GetPluginInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPluginInfo not implemented")
 }
+func (UnimplementedMetashellDaemonServer) MetacommandPipeline(context.Context, *MetacommandPipelineRequest) (*MetacommandPipelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MetacommandPipeline not implemented")
+}
 func (UnimplementedMetashellDaemonServer) mustEmbedUnimplementedMetashellDaemonServer() {}
 
 // UnsafeMetashellDaemonServer may be embedded to opt out of forward compatibility for this service.
@@ -350,6 +366,24 @@ func _MetashellDaemon_GetPluginInfo_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MetashellDaemon_MetacommandPipeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetacommandPipelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetashellDaemonServer).MetacommandPipeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetashellDaemon_MetacommandPipeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetashellDaemonServer).MetacommandPipeline(ctx, req.(*MetacommandPipelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MetashellDaemon_ServiceDesc is the grpc.ServiceDesc for MetashellDaemon service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -369,6 +403,10 @@ var MetashellDaemon_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPluginInfo",
 			Handler:    _MetashellDaemon_GetPluginInfo_Handler,
 		},
+		{
+			MethodName: "MetacommandPipeline",
+			Handler:    _MetashellDaemon_MetacommandPipeline_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
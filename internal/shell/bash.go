@@ -0,0 +1,101 @@
+package shell
+
+import (
+	"fmt"
+	"text/template"
+)
+
+var bashHooks = template.Must(template.New("bash").Parse(`
+PROMPT_COMMAND=__postRun
+EXEC=({{.Exec}} shellclient)
+export METASHELL_CMD_KEY=INIT
+__METASHELL_SEQ=0
+
+__METASHELL_TTY=$(tty)
+coproc __METASHELL_SC { "${EXEC[@]}" --serve --tty "$__METASHELL_TTY"; }
+
+trap __preRun DEBUG
+
+__preRun() {
+	case "$BASH_COMMAND" in
+		$PROMPT_COMMAND|__reportLine)
+			;;
+		*)
+			[[ -n "$__METASHELL_RUNNING" || -z "${{.SessionEnv}}" ]] && return
+			__METASHELL_RUNNING=1
+			METASHELL_CMD_KEY="${{.SessionEnv}}-$((++__METASHELL_SEQ))"
+			printf '\e]{{.Marker}};%s\a' "$METASHELL_CMD_KEY"
+
+			local cmd
+			__commandLine
+			if [[ -n "${__METASHELL_SC[1]}" ]]; then
+				cmd="${cmd//\\/\\\\}"
+				printf 'pre %s %s\n' "$METASHELL_CMD_KEY" "${cmd//$'\n'/\\n}" >&"${__METASHELL_SC[1]}"
+			else
+				"${EXEC[@]}" --tty $__METASHELL_TTY --cmdKey $METASHELL_CMD_KEY --cmd "$cmd" >/dev/null
+			fi
+	esac
+}
+
+# __commandLine sets cmd to the full command line being run, as recorded in
+# the history. $BASH_COMMAND only holds the first simple command of the line,
+# so it is only used when the line did not make it into the history.
+__commandLine() {
+	local hist
+	hist="$(HISTTIMEFORMAT= builtin history 1)"
+	if [[ "$hist" =~ ^[[:space:]]*([0-9]+)\*?[[:space:]][[:space:]](.*)$ ]] &&
+		[[ "${BASH_REMATCH[1]}" != "$__METASHELL_HISTNUM" ]]; then
+		__METASHELL_HISTNUM="${BASH_REMATCH[1]}"
+		cmd="${BASH_REMATCH[2]}"
+	else
+		cmd="$BASH_COMMAND"
+	fi
+}
+
+__postRun() {
+	local ec={{.ExitStatus}}
+	__METASHELL_RUNNING=
+	[[ "$METASHELL_CMD_KEY" == INIT ]] && return
+
+	if [[ -n "${__METASHELL_SC[1]}" ]]; then
+		printf 'post %s %s\n' "$METASHELL_CMD_KEY" "$ec" >&"${__METASHELL_SC[1]}"
+	else
+		"${EXEC[@]}" --cmdKey $METASHELL_CMD_KEY --exit-code $ec
+	fi
+	METASHELL_CMD_KEY=INIT
+}
+
+__reportLine() {
+	local line="${READLINE_LINE//\\/\\\\}"
+	line="${line//$'\e'/\\e}"
+	printf '\e]{{.LineMarker}};%s\a' "${line//$'\a'/\\a}"
+}
+
+for __METASHELL_KEYMAP in emacs vi-insert vi-command; do
+	bind -m $__METASHELL_KEYMAP '"\e[{{.ClearLine}}~": kill-whole-line'
+	bind -m $__METASHELL_KEYMAP -x '"\e[{{.ReportLine}}~": __reportLine'
+done
+unset __METASHELL_KEYMAP
+`))
+
+type bash struct{}
+
+func init() { register(bash{}) }
+
+func (bash) Name() string { return "bash" }
+
+func (d bash) Hooks(exe string) string {
+	return renderHooks(bashHooks, d, exe)
+}
+
+func (d bash) Bootstrap(exe string) string {
+	return fmt.Sprintf(". <(%s install --shell %s)\n", d.Quote(exe), d.Name())
+}
+
+func (bash) Quote(s string) string { return posixQuote(s) }
+
+func (bash) ExitStatus() string { return "$?" }
+
+func (bash) ClearLine() []byte { return clearLine() }
+
+func (bash) ReportLine() []byte { return reportLine() }
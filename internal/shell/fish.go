@@ -0,0 +1,68 @@
+package shell
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+var fishHooks = template.Must(template.New("fish").Parse(`
+set -g EXEC {{.Exec}} shellclient
+set -gx METASHELL_CMD_KEY INIT
+set -g __METASHELL_SEQ 0
+set -g __METASHELL_TTY (tty)
+
+function __preRun --on-event fish_preexec
+	test -z "${{.SessionEnv}}"; and return
+	set -g __METASHELL_SEQ (math $__METASHELL_SEQ + 1)
+	set -gx METASHELL_CMD_KEY "${{.SessionEnv}}-$__METASHELL_SEQ"
+	printf '\e]{{.Marker}};%s\a' $METASHELL_CMD_KEY
+
+	$EXEC --tty $__METASHELL_TTY --cmdKey $METASHELL_CMD_KEY --cmd "$argv" >/dev/null
+end
+
+function __postRun --on-event fish_postexec
+	set -l ec {{.ExitStatus}}
+	test "$METASHELL_CMD_KEY" = INIT; and return
+
+	$EXEC --cmdKey $METASHELL_CMD_KEY --exit-code $ec
+	set -gx METASHELL_CMD_KEY INIT
+end
+
+function __reportLine
+	set -l line (commandline | string replace -a '\\' '\\\\' | string replace -a \e '\\e' | string replace -a \a '\\a' | string collect)
+	printf '\e]{{.LineMarker}};%s\a' "$line"
+end
+
+for mode in default insert visual
+	bind -M $mode \e\[{{.ClearLine}}~ kill-whole-line
+	bind -M $mode \e\[{{.ReportLine}}~ __reportLine
+end
+`))
+
+type fish struct{}
+
+func init() { register(fish{}) }
+
+func (fish) Name() string { return "fish" }
+
+func (d fish) Hooks(exe string) string {
+	return renderHooks(fishHooks, d, exe)
+}
+
+func (d fish) Bootstrap(exe string) string {
+	return fmt.Sprintf("%s install --shell %s | source\n", d.Quote(exe), d.Name())
+}
+
+// Quote single quotes s; unlike POSIX shells, fish allows escaping
+// backslashes and single quotes inside single quotes.
+func (fish) Quote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	return "'" + r.Replace(s) + "'"
+}
+
+func (fish) ExitStatus() string { return "$status" }
+
+func (fish) ClearLine() []byte { return clearLine() }
+
+func (fish) ReportLine() []byte { return reportLine() }
@@ -0,0 +1,145 @@
+package shell
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const (
+	// SessionEnv is the environment variable through which metashell hands
+	// its session nonce to the shell.
+	SessionEnv = "METASHELL_SESSION"
+	// CommandMarker is the OSC code of the escape sequence the hooks print
+	// right before a command runs; its payload is the key of the command.
+	CommandMarker = "6973"
+	// ClearLineKey is the code of the made up function key the hooks bind to
+	// killing the line being typed, in every editing mode of the shell.
+	ClearLineKey = "6974"
+	// ReportLineKey is the code of the made up function key the hooks bind
+	// to reporting the line being typed, which they print in an escape
+	// sequence with LineMarker as its OSC code.
+	ReportLineKey = "6975"
+	LineMarker    = "6975"
+)
+
+// Dialect knows how metashell integrates with a particular shell.
+type Dialect interface {
+	// Name is the name of the shell, as found in the basename of its path.
+	Name() string
+	// Hooks returns the script that installs the metashell hooks into the shell.
+	// exe is the path to the metashell binary.
+	Hooks(exe string) string
+	// Bootstrap returns the line written to a freshly started shell to load the hooks.
+	Bootstrap(exe string) string
+	// Quote quotes s so that the shell reads it back as a single word.
+	Quote(s string) string
+	// ExitStatus returns the expression that expands to the exit status of the last command.
+	ExitStatus() string
+	// ClearLine returns the keys that make the shell clear the line being typed.
+	ClearLine() []byte
+	// ReportLine returns the keys that make the shell report the line being typed.
+	ReportLine() []byte
+}
+
+var dialects = make(map[string]Dialect)
+
+func register(d Dialect) {
+	dialects[d.Name()] = d
+}
+
+// Lookup returns the dialect registered under name.
+func Lookup(name string) (Dialect, error) {
+	d, ok := dialects[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported shell: %s (supported: %s)",
+			name, strings.Join(Names(), ", "),
+		)
+	}
+	return d, nil
+}
+
+// FromPath returns the dialect for the shell at path, based on its basename.
+func FromPath(path string) (Dialect, error) {
+	return Lookup(filepath.Base(path))
+}
+
+// Names returns the names of all supported shells.
+func Names() []string {
+	names := make([]string, 0, len(dialects))
+	for name := range dialects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type hooksData struct {
+	Exec       string
+	ExitStatus string
+	SessionEnv string
+	Marker     string
+	ClearLine  string
+	ReportLine string
+	LineMarker string
+}
+
+func renderHooks(tmpl *template.Template, d Dialect, exe string) string {
+	var sb strings.Builder
+	err := tmpl.Execute(&sb, hooksData{
+		Exec:       d.Quote(exe),
+		ExitStatus: d.ExitStatus(),
+		SessionEnv: SessionEnv,
+		Marker:     CommandMarker,
+		ClearLine:  ClearLineKey,
+		ReportLine: ReportLineKey,
+		LineMarker: LineMarker,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sb.String()
+}
+
+// clearLine is the key sequence sent by the function key bound by the hooks
+// to clearing the line.
+func clearLine() []byte {
+	return []byte("\x1b[" + ClearLineKey + "~")
+}
+
+// reportLine is the key sequence sent by the function key bound by the hooks
+// to reporting the line.
+func reportLine() []byte {
+	return []byte("\x1b[" + ReportLineKey + "~")
+}
+
+// UnescapeLine decodes the line reported by the hooks, which escape the
+// backslashes, ESC and BEL characters in it as \\, \e and \a.
+func UnescapeLine(s string) string {
+	var (
+		sb      strings.Builder
+		escaped bool
+	)
+	for _, r := range s {
+		switch {
+		case !escaped && r == '\\':
+			escaped = true
+			continue
+		case escaped && r == 'e':
+			sb.WriteByte(0x1b)
+		case escaped && r == 'a':
+			sb.WriteByte(0x07)
+		default:
+			sb.WriteRune(r)
+		}
+		escaped = false
+	}
+	return sb.String()
+}
+
+// posixQuote single quotes s, escaping any embedded single quotes.
+func posixQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
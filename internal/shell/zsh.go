@@ -0,0 +1,72 @@
+package shell
+
+import (
+	"fmt"
+	"text/template"
+)
+
+var zshHooks = template.Must(template.New("zsh").Parse(`
+EXEC=({{.Exec}} shellclient)
+export METASHELL_CMD_KEY=INIT
+__METASHELL_SEQ=0
+
+coproc $EXEC --serve --tty $TTY
+exec {__METASHELL_SC_W}>&p {__METASHELL_SC_R}<&p
+
+autoload -Uz add-zsh-hook
+
+__preRun() {
+	[[ -z "${{.SessionEnv}}" ]] && return
+	METASHELL_CMD_KEY="${{.SessionEnv}}-$((++__METASHELL_SEQ))"
+	printf '\e]{{.Marker}};%s\a' "$METASHELL_CMD_KEY"
+
+	local cmd="${1//\\/\\\\}"
+	print -r -u $__METASHELL_SC_W -- "pre $METASHELL_CMD_KEY ${cmd//$'\n'/\\n}"
+}
+
+__postRun() {
+	local ec={{.ExitStatus}}
+	[[ "$METASHELL_CMD_KEY" == INIT ]] && return
+
+	print -r -u $__METASHELL_SC_W -- "post $METASHELL_CMD_KEY $ec"
+	METASHELL_CMD_KEY=INIT
+}
+
+add-zsh-hook preexec __preRun
+add-zsh-hook precmd __postRun
+
+__reportLine() {
+	local line="${BUFFER//\\/\\\\}"
+	line="${line//$'\e'/\\e}"
+	printf '\e]{{.LineMarker}};%s\a' "${line//$'\a'/\\a}"
+}
+zle -N __reportLine
+
+for __METASHELL_KEYMAP in emacs viins vicmd; do
+	bindkey -M $__METASHELL_KEYMAP '\e[{{.ClearLine}}~' kill-whole-line
+	bindkey -M $__METASHELL_KEYMAP '\e[{{.ReportLine}}~' __reportLine
+done
+unset __METASHELL_KEYMAP
+`))
+
+type zsh struct{}
+
+func init() { register(zsh{}) }
+
+func (zsh) Name() string { return "zsh" }
+
+func (d zsh) Hooks(exe string) string {
+	return renderHooks(zshHooks, d, exe)
+}
+
+func (d zsh) Bootstrap(exe string) string {
+	return fmt.Sprintf(". <(%s install --shell %s)\n", d.Quote(exe), d.Name())
+}
+
+func (zsh) Quote(s string) string { return posixQuote(s) }
+
+func (zsh) ExitStatus() string { return "$?" }
+
+func (zsh) ClearLine() []byte { return clearLine() }
+
+func (zsh) ReportLine() []byte { return reportLine() }
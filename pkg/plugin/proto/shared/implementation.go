@@ -48,6 +48,10 @@ func (s *DaemonPluginServer) ReportCommand(ctx context.Context, rep *proto.Repor
 func (s *DaemonPluginServer) Metacommand(ctx context.Context, req *proto.MetacommandRequest) (*proto.MetacommandResponse, error) {
 	resp, err := s.Impl.Metacommand(ctx, req)
 	if err != nil {
+		if resp == nil {
+			// e.g. a plugin giving up once ctx is canceled
+			resp = &proto.MetacommandResponse{}
+		}
 		resp.Error = err.Error()
 	}
 